@@ -0,0 +1,87 @@
+package aws
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	dxVlanMin = 1
+	dxVlanMax = 4094
+)
+
+func dataSourceAwsDxAvailableVlan() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsDxAvailableVlanRead,
+
+		Schema: map[string]*schema.Schema{
+			"connection_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"random": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"vlan": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsDxAvailableVlanRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dxconn
+
+	connectionId := d.Get("connection_id").(string)
+	vifs, err := dxConnectionVirtualInterfaces(conn, connectionId)
+	if err != nil {
+		return err
+	}
+
+	usedVlans := map[int]bool{}
+	for _, vif := range vifs {
+		usedVlans[int(aws.Int64Value(vif.Vlan))] = true
+	}
+
+	vlan, ok := dxFreeVlan(usedVlans, d.Get("random").(bool))
+	if !ok {
+		return fmt.Errorf("no VLAN is available on Direct Connect connection (%s)", connectionId)
+	}
+
+	d.SetId(connectionId)
+	d.Set("vlan", vlan)
+
+	return nil
+}
+
+// dxFreeVlan returns the lowest VLAN, or a randomly chosen VLAN, that is not in use.
+// false is returned if every VLAN is in use.
+func dxFreeVlan(usedVlans map[int]bool, random bool) (int, bool) {
+	var freeVlans []int
+	for vlan := dxVlanMin; vlan <= dxVlanMax; vlan++ {
+		if usedVlans[vlan] {
+			continue
+		}
+
+		if !random {
+			return vlan, true
+		}
+
+		freeVlans = append(freeVlans, vlan)
+	}
+
+	if len(freeVlans) == 0 {
+		return 0, false
+	}
+
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	return freeVlans[r.Intn(len(freeVlans))], true
+}
@@ -0,0 +1,107 @@
+package aws
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDxFreeVlan(t *testing.T) {
+	if vlan, ok := dxFreeVlan(map[int]bool{}, false); !ok || vlan != 1 {
+		t.Errorf("expected VLAN 1, got %d (%t)", vlan, ok)
+	}
+
+	if vlan, ok := dxFreeVlan(map[int]bool{1: true, 2: true, 4: true}, false); !ok || vlan != 3 {
+		t.Errorf("expected VLAN 3, got %d (%t)", vlan, ok)
+	}
+
+	usedVlans := map[int]bool{}
+	for vlan := dxVlanMin; vlan <= dxVlanMax; vlan++ {
+		if vlan != 1234 {
+			usedVlans[vlan] = true
+		}
+	}
+
+	if vlan, ok := dxFreeVlan(usedVlans, true); !ok || vlan != 1234 {
+		t.Errorf("expected VLAN 1234, got %d (%t)", vlan, ok)
+	}
+
+	usedVlans[1234] = true
+
+	if vlan, ok := dxFreeVlan(usedVlans, false); ok {
+		t.Errorf("expected no VLAN, got %d", vlan)
+	}
+	if vlan, ok := dxFreeVlan(usedVlans, true); ok {
+		t.Errorf("expected no VLAN, got %d", vlan)
+	}
+}
+
+func TestDataSourceAwsDxAvailableVlanRead(t *testing.T) {
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{
+				{
+					Vlan:                  aws.Int64(1),
+					VirtualInterfaceId:    aws.String("dxvif-11111111"),
+					VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+				},
+				{
+					Vlan:                  aws.Int64(2),
+					VirtualInterfaceId:    aws.String("dxvif-22222222"),
+					VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateDeleted),
+				},
+			}
+		}
+	})
+
+	d := dataSourceAwsDxAvailableVlan().Data(nil)
+	d.Set("connection_id", "dxcon-12345678")
+
+	if err := dataSourceAwsDxAvailableVlanRead(d, &AWSClient{dxconn: conn}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The VLAN of a deleted virtual interface is available.
+	if got, expected := d.Get("vlan").(int), 2; got != expected {
+		t.Errorf("got vlan %d, expected %d", got, expected)
+	}
+}
+
+func TestAccDataSourceAwsDxAvailableVlan_basic(t *testing.T) {
+	key := "DX_CONNECTION_ID"
+	connectionId := os.Getenv(key)
+	if connectionId == "" {
+		t.Skipf("Environment variable %s is not set", key)
+	}
+
+	datasourceName := "data.aws_dx_available_vlan.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { testAccPreCheck(t) },
+		ErrorCheck: testAccErrorCheck(t, directconnect.EndpointsID),
+		Providers:  testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAwsDxAvailableVlanConfig(connectionId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "connection_id", connectionId),
+					resource.TestCheckResourceAttrSet(datasourceName, "vlan"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAwsDxAvailableVlanConfig(connectionId string) string {
+	return fmt.Sprintf(`
+data "aws_dx_available_vlan" "test" {
+  connection_id = %[1]q
+}
+`, connectionId)
+}
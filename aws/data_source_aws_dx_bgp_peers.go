@@ -0,0 +1,125 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAwsDxBgpPeers() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsDxBgpPeersRead,
+
+		Schema: map[string]*schema.Schema{
+			"bgp_peers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address_family": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"amazon_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"aws_device": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"bgp_asn": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"bgp_peer_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"bgp_peer_state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"bgp_status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"connection_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"customer_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"virtual_interface_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"connection_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsDxBgpPeersRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dxconn
+
+	vifs, err := dxListAllVirtualInterfaces(conn, d.Get("connection_id").(string), "")
+	if err != nil {
+		return fmt.Errorf("error reading Direct Connect virtual interfaces: %w", err)
+	}
+
+	if err := d.Set("bgp_peers", flattenDxBgpPeersNotUp(vifs)); err != nil {
+		return fmt.Errorf("error setting bgp_peers: %w", err)
+	}
+
+	d.SetId(meta.(*AWSClient).region)
+
+	return nil
+}
+
+// flattenDxBgpPeersNotUp flattens the BGP peers of the specified virtual interfaces
+// whose BGP status is not "up". Deleted virtual interfaces and BGP peers that are being deleted are expected to be down, so are skipped.
+func flattenDxBgpPeersNotUp(vifs []*directconnect.VirtualInterface) []interface{} {
+	tfList := []interface{}{}
+
+	for _, vif := range vifs {
+		if vif == nil || dxVirtualInterfaceStateIsTerminal(aws.StringValue(vif.VirtualInterfaceState)) {
+			continue
+		}
+
+		for _, bgpPeer := range vif.BgpPeers {
+			if bgpPeer == nil || aws.StringValue(bgpPeer.BgpStatus) == directconnect.BgpStatusUp {
+				continue
+			}
+
+			switch aws.StringValue(bgpPeer.BgpPeerState) {
+			case directconnect.BGPPeerStateDeleting, directconnect.BGPPeerStateDeleted:
+				continue
+			}
+
+			tfList = append(tfList, map[string]interface{}{
+				"address_family":       aws.StringValue(bgpPeer.AddressFamily),
+				"amazon_address":       aws.StringValue(bgpPeer.AmazonAddress),
+				"aws_device":           aws.StringValue(bgpPeer.AwsDeviceV2),
+				"bgp_asn":              int(aws.Int64Value(bgpPeer.Asn)),
+				"bgp_peer_id":          aws.StringValue(bgpPeer.BgpPeerId),
+				"bgp_peer_state":       aws.StringValue(bgpPeer.BgpPeerState),
+				"bgp_status":           aws.StringValue(bgpPeer.BgpStatus),
+				"connection_id":        aws.StringValue(vif.ConnectionId),
+				"customer_address":     aws.StringValue(bgpPeer.CustomerAddress),
+				"virtual_interface_id": aws.StringValue(vif.VirtualInterfaceId),
+			})
+		}
+	}
+
+	return tfList
+}
@@ -0,0 +1,101 @@
+package aws
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestFlattenDxBgpPeersNotUp(t *testing.T) {
+	vifs := []*directconnect.VirtualInterface{
+		{
+			ConnectionId:       aws.String("dxcon-11111111"),
+			VirtualInterfaceId: aws.String("dxvif-11111111"),
+			BgpPeers: []*directconnect.BGPPeer{
+				{
+					BgpPeerId: aws.String("dxpeer-11111111"),
+					BgpStatus: aws.String(directconnect.BgpStatusUp),
+				},
+				{
+					Asn:       aws.Int64(65000),
+					BgpPeerId: aws.String("dxpeer-22222222"),
+					BgpStatus: aws.String(directconnect.BgpStatusDown),
+				},
+				{
+					BgpPeerId:    aws.String("dxpeer-33333333"),
+					BgpPeerState: aws.String(directconnect.BGPPeerStateDeleting),
+					BgpStatus:    aws.String(directconnect.BgpStatusDown),
+				},
+			},
+		},
+		{
+			ConnectionId:       aws.String("dxcon-22222222"),
+			VirtualInterfaceId: aws.String("dxvif-22222222"),
+		},
+		{
+			ConnectionId:          aws.String("dxcon-33333333"),
+			VirtualInterfaceId:    aws.String("dxvif-33333333"),
+			VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateDeleted),
+			BgpPeers: []*directconnect.BGPPeer{
+				{
+					BgpPeerId: aws.String("dxpeer-44444444"),
+					BgpStatus: aws.String(directconnect.BgpStatusDown),
+				},
+			},
+		},
+	}
+
+	got := flattenDxBgpPeersNotUp(vifs)
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 BGP peer, got %d", len(got))
+	}
+
+	tfMap := got[0].(map[string]interface{})
+	if v := tfMap["bgp_peer_id"]; v != "dxpeer-22222222" {
+		t.Errorf("expected bgp_peer_id dxpeer-22222222, got %v", v)
+	}
+	if v := tfMap["bgp_asn"]; v != 65000 {
+		t.Errorf("expected bgp_asn 65000, got %v", v)
+	}
+	if v := tfMap["virtual_interface_id"]; v != "dxvif-11111111" {
+		t.Errorf("expected virtual_interface_id dxvif-11111111, got %v", v)
+	}
+}
+
+func TestAccDataSourceAwsDxBgpPeers_ConnectionId(t *testing.T) {
+	key := "DX_CONNECTION_ID"
+	connectionId := os.Getenv(key)
+	if connectionId == "" {
+		t.Skipf("Environment variable %s is not set", key)
+	}
+
+	datasourceName := "data.aws_dx_bgp_peers.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { testAccPreCheck(t) },
+		ErrorCheck: testAccErrorCheck(t, directconnect.EndpointsID),
+		Providers:  testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAwsDxBgpPeersConfig_ConnectionId(connectionId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "connection_id", connectionId),
+					resource.TestCheckResourceAttrSet(datasourceName, "bgp_peers.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAwsDxBgpPeersConfig_ConnectionId(connectionId string) string {
+	return fmt.Sprintf(`
+data "aws_dx_bgp_peers" "test" {
+  connection_id = %[1]q
+}
+`, connectionId)
+}
@@ -0,0 +1,108 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAwsDxConnectionMacsecStatus() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsDxConnectionMacsecStatusRead,
+
+		Schema: map[string]*schema.Schema{
+			"connection_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"encryption_mode": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"mac_sec_capable": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"macsec_keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ckn": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"start_on": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"port_encryption_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsDxConnectionMacsecStatusRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dxconn
+	connectionId := d.Get("connection_id").(string)
+
+	resp, err := conn.DescribeConnections(&directconnect.DescribeConnectionsInput{
+		ConnectionId: aws.String(connectionId),
+	})
+	if err != nil {
+		return fmt.Errorf("error reading Direct Connect connection (%s): %w", connectionId, err)
+	}
+
+	var connection *directconnect.Connection
+	for _, v := range resp.Connections {
+		if aws.StringValue(v.ConnectionId) == connectionId {
+			connection = v
+			break
+		}
+	}
+
+	if connection == nil {
+		return fmt.Errorf("Direct Connect connection (%s) not found", connectionId)
+	}
+
+	d.SetId(connectionId)
+	d.Set("encryption_mode", connection.EncryptionMode)
+	d.Set("mac_sec_capable", connection.MacSecCapable)
+	if err := d.Set("macsec_keys", flattenDxMacSecKeys(connection.MacSecKeys)); err != nil {
+		return fmt.Errorf("error setting macsec_keys: %w", err)
+	}
+	d.Set("port_encryption_status", connection.PortEncryptionStatus)
+
+	return nil
+}
+
+// flattenDxMacSecKeys returns the connectivity association key names (CKNs) and states of the MACsec keys.
+// The connectivity association keys (CAKs) themselves are never returned by the API.
+func flattenDxMacSecKeys(macSecKeys []*directconnect.MacSecKey) []interface{} {
+	tfList := []interface{}{}
+
+	for _, macSecKey := range macSecKeys {
+		if macSecKey == nil {
+			continue
+		}
+
+		tfList = append(tfList, map[string]interface{}{
+			"ckn":      aws.StringValue(macSecKey.Ckn),
+			"start_on": aws.StringValue(macSecKey.StartOn),
+			"state":    aws.StringValue(macSecKey.State),
+		})
+	}
+
+	return tfList
+}
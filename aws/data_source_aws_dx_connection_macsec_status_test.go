@@ -0,0 +1,74 @@
+package aws
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestFlattenDxMacSecKeys(t *testing.T) {
+	macSecKeys := []*directconnect.MacSecKey{
+		{
+			Ckn:       aws.String("0123456789abcdef"),
+			SecretARN: aws.String("arn:aws:secretsmanager:us-east-1:123456789012:secret:directconnect!prod/us-east-1/directconnect/0123456789abcdef-abcdef"),
+			StartOn:   aws.String("2021-06-01T00:00:00Z"),
+			State:     aws.String("associated"),
+		},
+		nil,
+	}
+
+	got := flattenDxMacSecKeys(macSecKeys)
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 MACsec key, got %d", len(got))
+	}
+
+	tfMap := got[0].(map[string]interface{})
+	if v := tfMap["ckn"]; v != "0123456789abcdef" {
+		t.Errorf("expected ckn 0123456789abcdef, got %v", v)
+	}
+	if v := tfMap["state"]; v != "associated" {
+		t.Errorf("expected state associated, got %v", v)
+	}
+	if _, ok := tfMap["secret_arn"]; ok {
+		t.Error("expected secret_arn not to be flattened")
+	}
+}
+
+func TestAccDataSourceAwsDxConnectionMacsecStatus_basic(t *testing.T) {
+	key := "DX_CONNECTION_ID"
+	connectionId := os.Getenv(key)
+	if connectionId == "" {
+		t.Skipf("Environment variable %s is not set", key)
+	}
+
+	datasourceName := "data.aws_dx_connection_macsec_status.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { testAccPreCheck(t) },
+		ErrorCheck: testAccErrorCheck(t, directconnect.EndpointsID),
+		Providers:  testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAwsDxConnectionMacsecStatusConfig_basic(connectionId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "connection_id", connectionId),
+					resource.TestCheckResourceAttrSet(datasourceName, "mac_sec_capable"),
+					resource.TestCheckResourceAttrSet(datasourceName, "macsec_keys.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAwsDxConnectionMacsecStatusConfig_basic(connectionId string) string {
+	return fmt.Sprintf(`
+data "aws_dx_connection_macsec_status" "test" {
+  connection_id = %[1]q
+}
+`, connectionId)
+}
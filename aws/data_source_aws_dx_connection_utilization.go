@@ -0,0 +1,162 @@
+package aws
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAwsDxConnectionUtilization() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsDxConnectionUtilizationRead,
+
+		Schema: map[string]*schema.Schema{
+			"bandwidth": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bandwidth_mbps": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"connection_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"virtual_interface_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"virtual_interfaces": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"mtu": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vlan": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"vlans": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+	}
+}
+
+func dataSourceAwsDxConnectionUtilizationRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dxconn
+
+	connectionId := d.Get("connection_id").(string)
+	connection, err := dxConnectionLookup(conn, connectionId)
+	if err != nil {
+		return fmt.Errorf("error reading Direct Connect connection (%s): %w", connectionId, err)
+	}
+	if connection == nil {
+		return fmt.Errorf("Direct Connect connection (%s) not found", connectionId)
+	}
+
+	vifs, err := dxListAllVirtualInterfaces(conn, connectionId, "")
+	if err != nil {
+		return fmt.Errorf("error reading Direct Connect connection (%s) virtual interfaces: %w", connectionId, err)
+	}
+
+	tfList, vlans := flattenDxConnectionUtilizationVirtualInterfaces(vifs)
+
+	d.SetId(connectionId)
+	d.Set("bandwidth", connection.Bandwidth)
+	d.Set("bandwidth_mbps", dxBandwidthMbps(aws.StringValue(connection.Bandwidth)))
+	d.Set("virtual_interface_count", len(tfList))
+	if err := d.Set("virtual_interfaces", tfList); err != nil {
+		return fmt.Errorf("error setting virtual_interfaces: %w", err)
+	}
+	if err := d.Set("vlans", vlans); err != nil {
+		return fmt.Errorf("error setting vlans: %w", err)
+	}
+
+	return nil
+}
+
+// flattenDxConnectionUtilizationVirtualInterfaces flattens the virtual interfaces that have not been deleted,
+// in VLAN order, and returns the VLANs they use.
+func flattenDxConnectionUtilizationVirtualInterfaces(vifs []*directconnect.VirtualInterface) ([]interface{}, []int) {
+	var active []*directconnect.VirtualInterface
+	for _, vif := range vifs {
+		if vif == nil || dxVirtualInterfaceStateIsTerminal(aws.StringValue(vif.VirtualInterfaceState)) {
+			continue
+		}
+
+		active = append(active, vif)
+	}
+
+	sort.SliceStable(active, func(i, j int) bool {
+		return aws.Int64Value(active[i].Vlan) < aws.Int64Value(active[j].Vlan)
+	})
+
+	tfList := []interface{}{}
+	vlans := []int{}
+	for _, vif := range active {
+		tfList = append(tfList, map[string]interface{}{
+			"id":    aws.StringValue(vif.VirtualInterfaceId),
+			"mtu":   int(aws.Int64Value(vif.Mtu)),
+			"name":  aws.StringValue(vif.VirtualInterfaceName),
+			"state": aws.StringValue(vif.VirtualInterfaceState),
+			"type":  aws.StringValue(vif.VirtualInterfaceType),
+			"vlan":  int(aws.Int64Value(vif.Vlan)),
+		})
+		vlans = append(vlans, int(aws.Int64Value(vif.Vlan)))
+	}
+
+	return tfList, vlans
+}
+
+var dxBandwidthRegexp = regexp.MustCompile(`^(\d+)(Mbps|Gbps)$`)
+
+// dxBandwidthMbps returns a Direct Connect bandwidth, e.g. "500Mbps" or "10Gbps", in Mbps.
+// Zero is returned if the bandwidth cannot be parsed.
+func dxBandwidthMbps(bandwidth string) int {
+	m := dxBandwidthRegexp.FindStringSubmatch(bandwidth)
+	if m == nil {
+		return 0
+	}
+
+	v, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0
+	}
+
+	if m[2] == "Gbps" {
+		return v * 1000
+	}
+
+	return v
+}
@@ -0,0 +1,120 @@
+package aws
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestFlattenDxConnectionUtilizationVirtualInterfaces(t *testing.T) {
+	vifs := []*directconnect.VirtualInterface{
+		{
+			Mtu:                   aws.Int64(9001),
+			Vlan:                  aws.Int64(200),
+			VirtualInterfaceId:    aws.String("dxvif-22222222"),
+			VirtualInterfaceName:  aws.String("second"),
+			VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+			VirtualInterfaceType:  aws.String("transit"),
+		},
+		{
+			Mtu:                   aws.Int64(1500),
+			Vlan:                  aws.Int64(100),
+			VirtualInterfaceId:    aws.String("dxvif-11111111"),
+			VirtualInterfaceName:  aws.String("first"),
+			VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateDown),
+			VirtualInterfaceType:  aws.String("private"),
+		},
+		{
+			Vlan:                  aws.Int64(300),
+			VirtualInterfaceId:    aws.String("dxvif-33333333"),
+			VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateDeleted),
+		},
+		nil,
+	}
+
+	tfList, vlans := flattenDxConnectionUtilizationVirtualInterfaces(vifs)
+
+	if expected := []int{100, 200}; !reflect.DeepEqual(vlans, expected) {
+		t.Errorf("expected vlans %v, got %v", expected, vlans)
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"id":    "dxvif-11111111",
+			"mtu":   1500,
+			"name":  "first",
+			"state": directconnect.VirtualInterfaceStateDown,
+			"type":  "private",
+			"vlan":  100,
+		},
+		map[string]interface{}{
+			"id":    "dxvif-22222222",
+			"mtu":   9001,
+			"name":  "second",
+			"state": directconnect.VirtualInterfaceStateAvailable,
+			"type":  "transit",
+			"vlan":  200,
+		},
+	}
+	if !reflect.DeepEqual(tfList, expected) {
+		t.Errorf("expected virtual interfaces %v, got %v", expected, tfList)
+	}
+}
+
+func TestDxBandwidthMbps(t *testing.T) {
+	cases := map[string]int{
+		"50Mbps":  50,
+		"500Mbps": 500,
+		"1Gbps":   1000,
+		"10Gbps":  10000,
+		"100Gbps": 100000,
+		"":        0,
+		"10 Gbps": 0,
+	}
+
+	for bandwidth, expected := range cases {
+		if got := dxBandwidthMbps(bandwidth); got != expected {
+			t.Errorf("%q: expected %d, got %d", bandwidth, expected, got)
+		}
+	}
+}
+
+func TestAccDataSourceAwsDxConnectionUtilization_basic(t *testing.T) {
+	key := "DX_CONNECTION_ID"
+	connectionId := os.Getenv(key)
+	if connectionId == "" {
+		t.Skipf("Environment variable %s is not set", key)
+	}
+
+	datasourceName := "data.aws_dx_connection_utilization.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { testAccPreCheck(t) },
+		ErrorCheck: testAccErrorCheck(t, directconnect.EndpointsID),
+		Providers:  testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAwsDxConnectionUtilizationConfig(connectionId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "connection_id", connectionId),
+					resource.TestCheckResourceAttrSet(datasourceName, "bandwidth"),
+					resource.TestCheckResourceAttrSet(datasourceName, "bandwidth_mbps"),
+					resource.TestCheckResourceAttrSet(datasourceName, "virtual_interface_count"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAwsDxConnectionUtilizationConfig(connectionId string) string {
+	return fmt.Sprintf(`
+data "aws_dx_connection_utilization" "test" {
+  connection_id = %[1]q
+}
+`, connectionId)
+}
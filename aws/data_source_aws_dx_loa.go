@@ -0,0 +1,79 @@
+package aws
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAwsDxLoa() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsDxLoaRead,
+
+		Schema: map[string]*schema.Schema{
+			"connection_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"connection_id", "interconnect_id", "lag_id"},
+			},
+			"content": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"content_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"interconnect_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"connection_id", "interconnect_id", "lag_id"},
+			},
+			"lag_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"connection_id", "interconnect_id", "lag_id"},
+			},
+			"provider_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsDxLoaRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dxconn
+
+	// DescribeLoa accepts the ID of a connection, LAG or interconnect as the connection ID.
+	id := d.Get("connection_id").(string)
+	if v, ok := d.GetOk("interconnect_id"); ok {
+		id = v.(string)
+	}
+	if v, ok := d.GetOk("lag_id"); ok {
+		id = v.(string)
+	}
+
+	input := &directconnect.DescribeLoaInput{
+		ConnectionId:   aws.String(id),
+		LoaContentType: aws.String(directconnect.LoaContentTypeApplicationPdf),
+	}
+
+	if v, ok := d.GetOk("provider_name"); ok {
+		input.ProviderName = aws.String(v.(string))
+	}
+
+	loa, err := conn.DescribeLoa(input)
+	if err != nil {
+		return fmt.Errorf("error reading Direct Connect LOA-CFA (%s): %w", id, err)
+	}
+
+	d.SetId(id)
+	d.Set("content", base64.StdEncoding.EncodeToString(loa.LoaContent))
+	d.Set("content_type", loa.LoaContentType)
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package aws
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceAwsDxLoaRead(t *testing.T) {
+	var input *directconnect.DescribeLoaInput
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.Loa:
+			input = r.Params.(*directconnect.DescribeLoaInput)
+			data.LoaContent = []byte("%PDF-1.4")
+			data.LoaContentType = aws.String(directconnect.LoaContentTypeApplicationPdf)
+		}
+	})
+
+	d := dataSourceAwsDxLoa().Data(nil)
+	d.Set("lag_id", "dxlag-12345678")
+	d.Set("provider_name", "Example Carrier")
+
+	if err := dataSourceAwsDxLoaRead(d, &AWSClient{dxconn: conn}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, expected := aws.StringValue(input.ConnectionId), "dxlag-12345678"; got != expected {
+		t.Errorf("got ConnectionId %q in request, expected %q", got, expected)
+	}
+	if got, expected := aws.StringValue(input.ProviderName), "Example Carrier"; got != expected {
+		t.Errorf("got ProviderName %q in request, expected %q", got, expected)
+	}
+	if got, expected := d.Get("content").(string), "JVBERi0xLjQ="; got != expected {
+		t.Errorf("got content %q, expected %q", got, expected)
+	}
+	if got, expected := d.Get("content_type").(string), directconnect.LoaContentTypeApplicationPdf; got != expected {
+		t.Errorf("got content_type %q, expected %q", got, expected)
+	}
+}
+
+func TestDataSourceAwsDxLoaRead_interconnectId(t *testing.T) {
+	var input *directconnect.DescribeLoaInput
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.Loa:
+			input = r.Params.(*directconnect.DescribeLoaInput)
+			data.LoaContentType = aws.String(directconnect.LoaContentTypeApplicationPdf)
+		}
+	})
+
+	d := dataSourceAwsDxLoa().Data(nil)
+	d.Set("interconnect_id", "dxcon-12345678")
+
+	if err := dataSourceAwsDxLoaRead(d, &AWSClient{dxconn: conn}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, expected := aws.StringValue(input.ConnectionId), "dxcon-12345678"; got != expected {
+		t.Errorf("got ConnectionId %q in request, expected %q", got, expected)
+	}
+	if got, expected := d.Id(), "dxcon-12345678"; got != expected {
+		t.Errorf("got ID %q, expected %q", got, expected)
+	}
+}
+
+func TestAccDataSourceAwsDxLoa_basic(t *testing.T) {
+	key := "DX_CONNECTION_ID"
+	connectionId := os.Getenv(key)
+	if connectionId == "" {
+		t.Skipf("Environment variable %s is not set", key)
+	}
+
+	datasourceName := "data.aws_dx_loa.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { testAccPreCheck(t) },
+		ErrorCheck: testAccErrorCheck(t, directconnect.EndpointsID),
+		Providers:  testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAwsDxLoaConfig_basic(connectionId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "connection_id", connectionId),
+					resource.TestCheckResourceAttrSet(datasourceName, "content"),
+					resource.TestCheckResourceAttr(datasourceName, "content_type", directconnect.LoaContentTypeApplicationPdf),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAwsDxLoaConfig_basic(cid string) string {
+	return fmt.Sprintf(`
+data "aws_dx_loa" "test" {
+  connection_id = %[1]q
+}
+`, cid)
+}
@@ -0,0 +1,186 @@
+package aws
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+)
+
+func dataSourceAwsDxVirtualInterface() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsDxVirtualInterfaceRead,
+
+		Schema: map[string]*schema.Schema{
+			"address_family": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"amazon_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"amazon_side_asn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"aws_device": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bgp_asn": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"bgp_hold_time": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"bgp_keepalive": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"connection_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"customer_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"dx_gateway_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"jumbo_frame_capable": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"mtu": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"owner_account_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"router_type_identifier": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags": tagsSchemaComputed(),
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vlan": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"vpn_gateway_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsDxVirtualInterfaceRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dxconn
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+
+	vifs, err := dxListAllVirtualInterfaces(conn, d.Get("connection_id").(string), "")
+	if err != nil {
+		return fmt.Errorf("error reading Direct Connect virtual interfaces: %w", err)
+	}
+
+	// DescribeVirtualInterfaces does not support filtering by tags.
+	filterTags := keyvaluetags.New(d.Get("tags").(map[string]interface{}))
+
+	var matches []*directconnect.VirtualInterface
+	var matchesArn string
+	var matchesTags keyvaluetags.KeyValueTags
+	for _, vif := range vifs {
+		if dxVirtualInterfaceStateIsTerminal(aws.StringValue(vif.VirtualInterfaceState)) {
+			continue
+		}
+
+		vifArn := dxVirtualInterfaceArn(meta.(*AWSClient), aws.StringValue(vif.OwnerAccount), aws.StringValue(vif.VirtualInterfaceId))
+
+		tags, err := keyvaluetags.DirectconnectListTags(conn, vifArn)
+		if err != nil {
+			return fmt.Errorf("error listing tags for Direct Connect virtual interface (%s): %w", vifArn, err)
+		}
+
+		if !tags.ContainsAll(filterTags) {
+			continue
+		}
+
+		matches = append(matches, vif)
+		matchesArn = vifArn
+		matchesTags = tags
+	}
+
+	if len(matches) == 0 {
+		return fmt.Errorf("no Direct Connect virtual interface matched; change the search criteria and try again")
+	}
+
+	if len(matches) > 1 {
+		return fmt.Errorf("%d Direct Connect virtual interfaces matched; use additional constraints to reduce matches to a single virtual interface", len(matches))
+	}
+
+	vif := matches[0]
+
+	d.SetId(aws.StringValue(vif.VirtualInterfaceId))
+	d.Set("address_family", vif.AddressFamily)
+	d.Set("amazon_address", vif.AmazonAddress)
+	d.Set("amazon_side_asn", strconv.FormatInt(aws.Int64Value(vif.AmazonSideAsn), 10))
+	d.Set("arn", matchesArn)
+	d.Set("aws_device", vif.AwsDeviceV2)
+	d.Set("bgp_asn", vif.Asn)
+	d.Set("connection_id", vif.ConnectionId)
+	d.Set("customer_address", vif.CustomerAddress)
+	d.Set("dx_gateway_id", vif.DirectConnectGatewayId)
+	d.Set("jumbo_frame_capable", vif.JumboFrameCapable)
+	d.Set("mtu", vif.Mtu)
+	d.Set("name", vif.VirtualInterfaceName)
+	d.Set("owner_account_id", vif.OwnerAccount)
+	d.Set("state", vif.VirtualInterfaceState)
+	d.Set("type", vif.VirtualInterfaceType)
+	d.Set("vlan", vif.Vlan)
+	d.Set("vpn_gateway_id", vif.VirtualGatewayId)
+
+	// BGP timers are not exposed as virtual interface attributes, so they are parsed from the sample router configuration.
+	if v, ok := d.GetOk("router_type_identifier"); ok {
+		routerConfig, err := dxVirtualInterfaceRouterConfiguration(conn, aws.StringValue(vif.VirtualInterfaceId), v.(string))
+		if err != nil {
+			return err
+		}
+
+		keepalive, holdTime := dxRouterConfigurationBgpTimers(routerConfig)
+		d.Set("bgp_hold_time", holdTime)
+		d.Set("bgp_keepalive", keepalive)
+	}
+
+	if err := d.Set("tags", dxFilterSystemTags(matchesTags, ignoreTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package aws
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceAwsDxVirtualInterface_Tags(t *testing.T) {
+	key := "DX_CONNECTION_ID"
+	connectionId := os.Getenv(key)
+	if connectionId == "" {
+		t.Skipf("Environment variable %s is not set", key)
+	}
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_dx_private_virtual_interface.test"
+	datasourceName := "data.aws_dx_virtual_interface.test"
+	bgpAsn := acctest.RandIntRange(64512, 65534)
+	vlan := acctest.RandIntRange(2049, 4094)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { testAccPreCheck(t) },
+		ErrorCheck: testAccErrorCheck(t, directconnect.EndpointsID),
+		Providers:  testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDataSourceAwsDxVirtualInterfaceConfig_NonExistent(connectionId),
+				ExpectError: regexp.MustCompile(`no Direct Connect virtual interface matched`),
+			},
+			{
+				Config: testAccDataSourceAwsDxVirtualInterfaceConfig_Tags(connectionId, rName, bgpAsn, vlan),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(datasourceName, "arn", resourceName, "arn"),
+					resource.TestCheckResourceAttrPair(datasourceName, "bgp_asn", resourceName, "bgp_asn"),
+					resource.TestCheckResourceAttrPair(datasourceName, "connection_id", resourceName, "connection_id"),
+					resource.TestCheckResourceAttrPair(datasourceName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(datasourceName, "name", resourceName, "name"),
+					resource.TestCheckResourceAttr(datasourceName, "tags.%", "2"),
+					resource.TestCheckResourceAttr(datasourceName, "type", "private"),
+					resource.TestCheckResourceAttrPair(datasourceName, "vlan", resourceName, "vlan"),
+					resource.TestCheckResourceAttrPair(datasourceName, "vpn_gateway_id", resourceName, "vpn_gateway_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAwsDxVirtualInterfaceConfig_NonExistent(cid string) string {
+	return fmt.Sprintf(`
+data "aws_dx_virtual_interface" "test" {
+  connection_id = %[1]q
+
+  tags = {
+    Name = "tf-acc-test-does-not-exist"
+  }
+}
+`, cid)
+}
+
+func testAccDataSourceAwsDxVirtualInterfaceConfig_Tags(cid, rName string, bgpAsn, vlan int) string {
+	return testAccDxPrivateVirtualInterfaceConfig_vpnGateway(rName) + fmt.Sprintf(`
+resource "aws_dx_private_virtual_interface" "test" {
+  address_family = "ipv4"
+  bgp_asn        = %[3]d
+  connection_id  = %[1]q
+  name           = %[2]q
+  vlan           = %[4]d
+  vpn_gateway_id = aws_vpn_gateway.test.id
+
+  tags = {
+    Name = %[2]q
+    Team = "net"
+  }
+}
+
+data "aws_dx_virtual_interface" "test" {
+  connection_id = aws_dx_private_virtual_interface.test.connection_id
+
+  tags = aws_dx_private_virtual_interface.test.tags
+}
+`, cid, rName, bgpAsn, vlan)
+}
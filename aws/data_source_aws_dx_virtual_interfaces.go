@@ -0,0 +1,117 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceAwsDxVirtualInterfaces() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsDxVirtualInterfacesRead,
+
+		Schema: map[string]*schema.Schema{
+			"connection_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"private", "public", "transit"}, false),
+			},
+			"virtual_interfaces": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"connection_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"owner_account_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAwsDxVirtualInterfacesRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dxconn
+
+	vifs, err := dxListAllVirtualInterfaces(conn, d.Get("connection_id").(string), d.Get("type").(string))
+	if err != nil {
+		return fmt.Errorf("error reading Direct Connect virtual interfaces: %w", err)
+	}
+
+	var ids []string
+	for _, vif := range vifs {
+		if dxVirtualInterfaceStateIsTerminal(aws.StringValue(vif.VirtualInterfaceState)) {
+			continue
+		}
+
+		ids = append(ids, aws.StringValue(vif.VirtualInterfaceId))
+	}
+
+	if err := d.Set("ids", ids); err != nil {
+		return fmt.Errorf("error setting ids: %w", err)
+	}
+
+	if err := d.Set("virtual_interfaces", flattenDxVirtualInterfaceSummaries(vifs)); err != nil {
+		return fmt.Errorf("error setting virtual_interfaces: %w", err)
+	}
+
+	d.SetId(meta.(*AWSClient).region)
+
+	return nil
+}
+
+// flattenDxVirtualInterfaceSummaries flattens the identifying attributes of the specified virtual interfaces that have not been deleted.
+func flattenDxVirtualInterfaceSummaries(vifs []*directconnect.VirtualInterface) []interface{} {
+	tfList := []interface{}{}
+
+	for _, vif := range vifs {
+		if vif == nil || dxVirtualInterfaceStateIsTerminal(aws.StringValue(vif.VirtualInterfaceState)) {
+			continue
+		}
+
+		tfList = append(tfList, map[string]interface{}{
+			"connection_id":    aws.StringValue(vif.ConnectionId),
+			"id":               aws.StringValue(vif.VirtualInterfaceId),
+			"name":             aws.StringValue(vif.VirtualInterfaceName),
+			"owner_account_id": aws.StringValue(vif.OwnerAccount),
+			"state":            aws.StringValue(vif.VirtualInterfaceState),
+			"type":             aws.StringValue(vif.VirtualInterfaceType),
+		})
+	}
+
+	return tfList
+}
@@ -0,0 +1,81 @@
+package aws
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestFlattenDxVirtualInterfaceSummaries(t *testing.T) {
+	vifs := []*directconnect.VirtualInterface{
+		{
+			ConnectionId:          aws.String("dxcon-11111111"),
+			OwnerAccount:          aws.String("123456789012"),
+			VirtualInterfaceId:    aws.String("dxvif-11111111"),
+			VirtualInterfaceName:  aws.String("example"),
+			VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+			VirtualInterfaceType:  aws.String("private"),
+		},
+		{
+			ConnectionId:          aws.String("dxcon-11111111"),
+			VirtualInterfaceId:    aws.String("dxvif-22222222"),
+			VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateDeleted),
+		},
+		nil,
+	}
+
+	got := flattenDxVirtualInterfaceSummaries(vifs)
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 virtual interface, got %d", len(got))
+	}
+
+	tfMap := got[0].(map[string]interface{})
+	if v := tfMap["id"]; v != "dxvif-11111111" {
+		t.Errorf("expected id dxvif-11111111, got %v", v)
+	}
+	if v := tfMap["owner_account_id"]; v != "123456789012" {
+		t.Errorf("expected owner_account_id 123456789012, got %v", v)
+	}
+	if v := tfMap["type"]; v != "private" {
+		t.Errorf("expected type private, got %v", v)
+	}
+}
+
+func TestAccDataSourceAwsDxVirtualInterfaces_ConnectionId(t *testing.T) {
+	key := "DX_CONNECTION_ID"
+	connectionId := os.Getenv(key)
+	if connectionId == "" {
+		t.Skipf("Environment variable %s is not set", key)
+	}
+
+	datasourceName := "data.aws_dx_virtual_interfaces.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { testAccPreCheck(t) },
+		ErrorCheck: testAccErrorCheck(t, directconnect.EndpointsID),
+		Providers:  testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAwsDxVirtualInterfacesConfig_ConnectionId(connectionId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceName, "connection_id", connectionId),
+					resource.TestCheckResourceAttrSet(datasourceName, "ids.#"),
+					resource.TestCheckResourceAttrSet(datasourceName, "virtual_interfaces.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAwsDxVirtualInterfacesConfig_ConnectionId(connectionId string) string {
+	return fmt.Sprintf(`
+data "aws_dx_virtual_interfaces" "test" {
+  connection_id = %[1]q
+}
+`, connectionId)
+}
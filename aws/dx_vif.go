@@ -1,29 +1,614 @@
 package aws
 
 import (
+	"bytes"
+	"context"
+	crand "crypto/rand"
 	"fmt"
 	"log"
+	"math/big"
+	"math/rand"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/directconnect"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
 )
 
+// dxVirtualInterfaceCustomizeDiff ensures that the BGP peer addresses of a new virtual interface are specified together.
+// For an existing virtual interface it warns, or errors if "strict_address_family" is set, when the ForceNew "address_family" argument changes,
+// and warns when both of the ForceNew "connection_id" and "vlan" arguments change in the same plan,
+// as this usually indicates an unintended move of the virtual interface to a different connection.
+func dxVirtualInterfaceCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" {
+		if !diff.NewValueKnown("amazon_address") || !diff.NewValueKnown("customer_address") {
+			return nil
+		}
+
+		return dxVirtualInterfaceValidatePeerAddresses(diff.Get("address_family").(string), diff.Get("amazon_address").(string), diff.Get("customer_address").(string))
+	}
+
+	if diff.HasChange("address_family") {
+		o, n := diff.GetChange("address_family")
+		msg := fmt.Sprintf("changing 'address_family' (%s => %s) recreates Direct Connect virtual interface (%s), dropping its BGP sessions. Consider adding a BGP peer of the new address family with an aws_dx_bgp_peer resource instead", o, n, diff.Id())
+		if diff.Get("strict_address_family").(bool) {
+			return fmt.Errorf("%s", msg)
+		}
+		log.Printf("[WARN] %s", msg)
+	}
+
+	if diff.HasChange("connection_id") && diff.HasChange("vlan") {
+		o, n := diff.GetChange("connection_id")
+		log.Printf("[WARN] Direct Connect virtual interface (%s) 'connection_id' (%s => %s) and 'vlan' are both changing, the virtual interface will be recreated on a different connection", diff.Id(), o, n)
+	}
+
+	return nil
+}
+
+const (
+	dxIpv6AddressAssignmentAuto   = "auto"
+	dxIpv6AddressAssignmentManual = "manual"
+)
+
+var dxIpv6AddressAssignments = []string{
+	dxIpv6AddressAssignmentAuto,
+	dxIpv6AddressAssignmentManual,
+}
+
+// dxVirtualInterfaceIpv6AddressAssignmentCustomizeDiff validates the BGP peer addresses of a new virtual interface
+// against the explicitly configured "ipv6_address_assignment".
+// For an existing virtual interface whose peer addresses are unchanged the assignment is only recorded, so just the address family is validated.
+func dxVirtualInterfaceIpv6AddressAssignmentCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() != "" && !diff.HasChange("ipv6_address_assignment") {
+		return nil
+	}
+	if diff.Id() != "" && !diff.HasChange("amazon_address") && !diff.HasChange("customer_address") {
+		if assignment, addressFamily := diff.Get("ipv6_address_assignment").(string), diff.Get("address_family").(string); assignment != "" && addressFamily != directconnect.AddressFamilyIpv6 {
+			return fmt.Errorf("'ipv6_address_assignment' can only be configured when 'address_family' is %s", directconnect.AddressFamilyIpv6)
+		}
+
+		return nil
+	}
+	if !diff.NewValueKnown("amazon_address") || !diff.NewValueKnown("customer_address") {
+		return nil
+	}
+
+	return dxVirtualInterfaceValidateIpv6AddressAssignment(diff.Get("ipv6_address_assignment").(string), diff.Get("address_family").(string), diff.Get("amazon_address").(string), diff.Get("customer_address").(string))
+}
+
+// dxVirtualInterfaceValidateIpv6AddressAssignment returns an error if the BGP peer addresses do not match the IPv6 address assignment.
+// Manual assignment requires both addresses, auto assignment leaves both to AWS.
+func dxVirtualInterfaceValidateIpv6AddressAssignment(assignment, addressFamily, amazonAddress, customerAddress string) error {
+	if assignment == "" {
+		return nil
+	}
+
+	if addressFamily != directconnect.AddressFamilyIpv6 {
+		return fmt.Errorf("'ipv6_address_assignment' can only be configured when 'address_family' is %s", directconnect.AddressFamilyIpv6)
+	}
+
+	switch assignment {
+	case dxIpv6AddressAssignmentAuto:
+		if amazonAddress != "" || customerAddress != "" {
+			return fmt.Errorf("'amazon_address' and 'customer_address' must be omitted when 'ipv6_address_assignment' is %s, AWS assigns them", assignment)
+		}
+	case dxIpv6AddressAssignmentManual:
+		if amazonAddress == "" || customerAddress == "" {
+			return fmt.Errorf("'amazon_address' and 'customer_address' must be specified when 'ipv6_address_assignment' is %s", assignment)
+		}
+	}
+
+	return nil
+}
+
+// validateDxTransitVirtualInterfaceGatewayId validates that a transit virtual interface's gateway is a Direct Connect gateway.
+// Transit virtual interfaces cannot be attached to a virtual private gateway, or directly to a transit gateway.
+func validateDxTransitVirtualInterfaceGatewayId(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	switch {
+	case value == "":
+		errors = append(errors, fmt.Errorf("%q must be the ID of the Direct Connect gateway to which to connect the transit virtual interface", k))
+	case strings.HasPrefix(value, "vgw-"):
+		errors = append(errors, fmt.Errorf("%q (%s) is a virtual private gateway ID, transit virtual interfaces can only be connected to a Direct Connect gateway. Use aws_dx_private_virtual_interface to connect to a virtual private gateway", k, value))
+	case strings.HasPrefix(value, "tgw-"):
+		errors = append(errors, fmt.Errorf("%q (%s) is a transit gateway ID, transit virtual interfaces can only be connected to a Direct Connect gateway. Associate the transit gateway with the Direct Connect gateway using aws_dx_gateway_association", k, value))
+	}
+
+	return
+}
+
+// dxVirtualInterfaceGatewayMigrationCustomizeDiff returns a CustomizeDiffFunc that handles a change of any of the specified gateway arguments of an existing virtual interface.
+// Direct Connect cannot move a virtual interface between gateways in place, so the virtual interface is recreated.
+// This is an error if "prevent_active_delete" is set and otherwise a warning.
+func dxVirtualInterfaceGatewayMigrationCustomizeDiff(keys ...string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+		if diff.Id() == "" {
+			return nil
+		}
+
+		for _, k := range keys {
+			if !diff.HasChange(k) {
+				continue
+			}
+
+			o, n := diff.GetChange(k)
+			msg := fmt.Sprintf("Direct Connect does not support moving virtual interface (%s) to a different gateway in place, changing '%s' (%s => %s) recreates it, dropping its BGP sessions. "+
+				"To migrate without downtime, create a new virtual interface on an unused VLAN connected to the new gateway, move traffic to it and then remove this virtual interface", diff.Id(), k, o, n)
+			if diff.Get("prevent_active_delete").(bool) {
+				return fmt.Errorf("%s", msg)
+			}
+			log.Printf("[WARN] %s", msg)
+		}
+
+		return nil
+	}
+}
+
+// dxVirtualInterfaceValidatePeerAddresses returns an error if only one of the BGP peer addresses is specified.
+// Both must be specified, or both omitted for AWS to assign them.
+// IPv6 peer addresses must be /125 or /126 CIDRs.
+func dxVirtualInterfaceValidatePeerAddresses(addressFamily, amazonAddress, customerAddress string) error {
+	if (amazonAddress == "") != (customerAddress == "") {
+		return fmt.Errorf("'amazon_address' and 'customer_address' must be specified together, or both omitted for AWS to assign them")
+	}
+
+	if addressFamily != directconnect.AddressFamilyIpv6 || amazonAddress == "" {
+		return nil
+	}
+
+	if err := dxVirtualInterfaceValidateIpv6PeerAddress(amazonAddress); err != nil {
+		return fmt.Errorf("'amazon_address' %s", err)
+	}
+	if err := dxVirtualInterfaceValidateIpv6PeerAddress(customerAddress); err != nil {
+		return fmt.Errorf("'customer_address' %s", err)
+	}
+
+	return nil
+}
+
+// dxPeerAddressesConsistent returns whether the specified BGP peer addresses are distinct addresses in the same subnet.
+func dxPeerAddressesConsistent(amazonAddress, customerAddress string) bool {
+	amazonIp, amazonNet, err := net.ParseCIDR(amazonAddress)
+	if err != nil {
+		return false
+	}
+
+	customerIp, customerNet, err := net.ParseCIDR(customerAddress)
+	if err != nil {
+		return false
+	}
+
+	return amazonNet.String() == customerNet.String() && !amazonIp.Equal(customerIp)
+}
+
+// dxVirtualInterfaceValidateIpv6PeerAddress returns an error if the specified address is not an IPv6 /125 or /126 CIDR.
+func dxVirtualInterfaceValidateIpv6PeerAddress(address string) error {
+	ip, ipnet, err := net.ParseCIDR(address)
+	if err != nil || ip.To4() != nil {
+		return fmt.Errorf("(%s) must be an IPv6 CIDR, e.g. 2001:db8::1/125", address)
+	}
+
+	if ones, _ := ipnet.Mask.Size(); ones != 125 && ones != 126 {
+		return fmt.Errorf("(%s) must have a /125 or /126 prefix length, got /%d", address, ones)
+	}
+
+	return nil
+}
+
+// dxVirtualInterfaceRouteFilterPrefixesCustomizeDiff warns when "route_filter_prefixes" or "ordered_route_filter_prefixes"
+// contains duplicate prefixes, likely a copy-paste error.
+// Identical prefixes are removed by the set before the diff can be customized, so for "route_filter_prefixes" only prefixes written differently
+// but denoting the same network, e.g. "175.45.176.1/22" and "175.45.176.0/22", can be detected.
+func dxVirtualInterfaceRouteFilterPrefixesCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	for _, k := range []string{"ordered_route_filter_prefixes", "route_filter_prefixes"} {
+		if !diff.HasChange(k) || !diff.NewValueKnown(k) {
+			continue
+		}
+
+		var cidrs []string
+		switch v := diff.Get(k).(type) {
+		case *schema.Set:
+			cidrs = aws.StringValueSlice(expandStringSet(v))
+		case []interface{}:
+			cidrs = aws.StringValueSlice(expandStringList(v))
+		}
+
+		for network, prefixes := range dxDuplicateCidrs(cidrs) {
+			log.Printf("[WARN] Direct Connect virtual interface '%s' %s all denote the network %s, is a prefix missing?", k, strings.Join(prefixes, ", "), network)
+		}
+	}
+
+	return nil
+}
+
+// dxVirtualInterfaceSuppressRouteFilterPrefixesOrderDiff suppresses differences in "ordered_route_filter_prefixes" that only change the order of the prefixes.
+// AWS does not preserve the order of route filter prefixes, so after import the prefixes are compared with "route_filter_prefixes".
+func dxVirtualInterfaceSuppressRouteFilterPrefixesOrderDiff(k, old, new string, d *schema.ResourceData) bool {
+	o, n := d.GetChange("ordered_route_filter_prefixes")
+	oldPrefixes, newPrefixes := o.([]interface{}), n.([]interface{})
+
+	if len(newPrefixes) == 0 {
+		return false
+	}
+
+	if len(oldPrefixes) == 0 {
+		if d.Id() == "" {
+			return false
+		}
+
+		return d.Get("route_filter_prefixes").(*schema.Set).Equal(schema.NewSet(schema.HashString, newPrefixes))
+	}
+
+	return schema.NewSet(schema.HashString, oldPrefixes).Equal(schema.NewSet(schema.HashString, newPrefixes))
+}
+
+// dxOrderedRouteFilterPrefixes returns the configured route filter prefixes in their configured order if they are the
+// virtual interface's route filter prefixes, otherwise the virtual interface's route filter prefixes in numeric CIDR order.
+func dxOrderedRouteFilterPrefixes(configured []interface{}, routeFilterPrefixes *schema.Set) []string {
+	if schema.NewSet(schema.HashString, configured).Equal(routeFilterPrefixes) {
+		return aws.StringValueSlice(expandStringList(configured))
+	}
+
+	return dxSortCidrs(aws.StringValueSlice(expandStringSet(routeFilterPrefixes)))
+}
+
+// dxDuplicateCidrs returns the CIDRs denoting the same network, keyed by that network.
+// Invalid CIDRs are ignored.
+func dxDuplicateCidrs(cidrs []string) map[string][]string {
+	networks := make(map[string][]string)
+	for _, cidr := range dxSortCidrs(cidrs) {
+		if _, ipnet, err := net.ParseCIDR(cidr); err == nil {
+			networks[ipnet.String()] = append(networks[ipnet.String()], cidr)
+		}
+	}
+
+	duplicates := make(map[string][]string)
+	for network, v := range networks {
+		if len(v) > 1 {
+			duplicates[network] = v
+		}
+	}
+
+	return duplicates
+}
+
+// dxSortCidrs returns the CIDRs sorted in numeric order, IPv4 before IPv6 and then by network address and prefix length,
+// for rendering in a deterministic order. Invalid CIDRs are sorted last.
+func dxSortCidrs(cidrs []string) []string {
+	type sortKey struct {
+		ip   net.IP
+		ones int
+	}
+
+	keys := make(map[string]*sortKey, len(cidrs))
+	for _, cidr := range cidrs {
+		if _, ipnet, err := net.ParseCIDR(cidr); err == nil {
+			ones, _ := ipnet.Mask.Size()
+			ip := ipnet.IP.To4()
+			if ip == nil {
+				ip = ipnet.IP.To16()
+			}
+			keys[cidr] = &sortKey{ip: ip, ones: ones}
+		}
+	}
+
+	sorted := make([]string, len(cidrs))
+	copy(sorted, cidrs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ki, kj := keys[sorted[i]], keys[sorted[j]]
+		switch {
+		case ki == nil || kj == nil:
+			if ki == nil && kj == nil {
+				return sorted[i] < sorted[j]
+			}
+			return kj == nil
+		case len(ki.ip) != len(kj.ip):
+			return len(ki.ip) < len(kj.ip)
+		case !ki.ip.Equal(kj.ip):
+			return bytes.Compare(ki.ip, kj.ip) < 0
+		default:
+			return ki.ones < kj.ones
+		}
+	})
+
+	return sorted
+}
+
+// dxHostedVirtualInterfaceAutoAcceptCustomizeDiff ensures that "auto_accept" is only enabled
+// when the hosted virtual interface is allocated to the caller's own account.
+func dxHostedVirtualInterfaceAutoAcceptCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.Get("auto_accept").(bool) || !diff.NewValueKnown("owner_account_id") {
+		return nil
+	}
+
+	if ownerAccountId, accountId := diff.Get("owner_account_id").(string), meta.(*AWSClient).accountid; ownerAccountId != accountId {
+		return fmt.Errorf("'auto_accept' can only be enabled when 'owner_account_id' (%s) is the caller's account (%s)", ownerAccountId, accountId)
+	}
+
+	return nil
+}
+
+// dxVirtualInterfaceArn returns the ARN of the virtual interface in the client's partition and region.
+// The service name is "directconnect" in every partition, including aws-cn and aws-us-gov.
+func dxVirtualInterfaceArn(client *AWSClient, accountId, vifId string) string {
+	return arn.ARN{
+		Partition: client.partition,
+		Region:    client.region,
+		Service:   "directconnect",
+		AccountID: accountId,
+		Resource:  fmt.Sprintf("dxvif/%s", vifId),
+	}.String()
+}
+
+const (
+	dxVirtualInterfaceRoleAccepter = "accepter"
+	dxVirtualInterfaceRoleCreator  = "creator"
+)
+
+var (
+	// Cisco IOS, IOS XE and NX-OS: "neighbor 169.254.255.1 timers 10 30".
+	dxRouterConfigurationTimersRegexp = regexp.MustCompile(`\btimers\s+(\d+)\s+(\d+)`)
+	// Junos: "hold-time 30;". Junos derives the keepalive interval as one third of the hold time.
+	dxRouterConfigurationHoldTimeRegexp = regexp.MustCompile(`\bhold-time\s+(\d+)`)
+)
+
+// dxRouterConfigurationBgpTimers returns the BGP keepalive interval and hold time, in seconds, from a sample router configuration
+// as returned by DescribeRouterConfiguration. Zero values are returned if the configuration does not specify the timers.
+func dxRouterConfigurationBgpTimers(config string) (int, int) {
+	if m := dxRouterConfigurationTimersRegexp.FindStringSubmatch(config); m != nil {
+		keepalive, _ := strconv.Atoi(m[1])
+		holdTime, _ := strconv.Atoi(m[2])
+
+		return keepalive, holdTime
+	}
+
+	if m := dxRouterConfigurationHoldTimeRegexp.FindStringSubmatch(config); m != nil {
+		holdTime, _ := strconv.Atoi(m[1])
+
+		return holdTime / 3, holdTime
+	}
+
+	return 0, 0
+}
+
+// dxVirtualInterfaceRouterConfiguration returns the sample customer router configuration for the virtual interface
+// and router type, or an empty string if no router type is specified.
+func dxVirtualInterfaceRouterConfiguration(conn *directconnect.DirectConnect, vifId, routerTypeIdentifier string) (string, error) {
+	if routerTypeIdentifier == "" {
+		return "", nil
+	}
+
+	output, err := conn.DescribeRouterConfiguration(&directconnect.DescribeRouterConfigurationInput{
+		RouterTypeIdentifier: aws.String(routerTypeIdentifier),
+		VirtualInterfaceId:   aws.String(vifId),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error reading Direct Connect virtual interface (%s) router configuration: %w", vifId, err)
+	}
+
+	return aws.StringValue(output.CustomerRouterConfig), nil
+}
+
+// dxVirtualInterfaceRole returns whether the caller's account is the creator or the accepter of the virtual interface.
+// A hosted virtual interface is owned by the account that accepts it, so only an accepter resource whose account owns
+// the virtual interface is the accepter; in all other cases the caller's account created the virtual interface.
+func dxVirtualInterfaceRole(vif *directconnect.VirtualInterface, callerAccountId string, accepter bool) string {
+	if accepter && aws.StringValue(vif.OwnerAccount) == callerAccountId {
+		return dxVirtualInterfaceRoleAccepter
+	}
+
+	return dxVirtualInterfaceRoleCreator
+}
+
+// dxListAllVirtualInterfaces returns all virtual interfaces, optionally only those on the specified connection or LAG
+// and of the specified type ("private", "public" or "transit").
+// DescribeVirtualInterfaces is not paginated and returns all virtual interfaces in a single response;
+// should pagination be added to the API, it only needs handling here.
+func dxListAllVirtualInterfaces(conn *directconnect.DirectConnect, connectionId, vifType string) ([]*directconnect.VirtualInterface, error) {
+	input := &directconnect.DescribeVirtualInterfacesInput{}
+	if connectionId != "" {
+		input.ConnectionId = aws.String(connectionId)
+	}
+
+	output, err := conn.DescribeVirtualInterfaces(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var vifs []*directconnect.VirtualInterface
+	for _, vif := range output.VirtualInterfaces {
+		if vif == nil {
+			continue
+		}
+
+		if vifType != "" && aws.StringValue(vif.VirtualInterfaceType) != vifType {
+			continue
+		}
+
+		vifs = append(vifs, vif)
+	}
+
+	return vifs, nil
+}
+
+// dxConnectionVirtualInterfaces returns the virtual interfaces on a connection or LAG that have not been deleted, ordered by ID.
+func dxConnectionVirtualInterfaces(conn *directconnect.DirectConnect, connectionId string) ([]*directconnect.VirtualInterface, error) {
+	allVifs, err := dxListAllVirtualInterfaces(conn, connectionId, "")
+	if err != nil {
+		return nil, fmt.Errorf("error reading Direct Connect virtual interfaces for connection (%s): %w", connectionId, err)
+	}
+
+	var vifs []*directconnect.VirtualInterface
+	for _, vif := range allVifs {
+		if dxVirtualInterfaceStateIsTerminal(aws.StringValue(vif.VirtualInterfaceState)) {
+			continue
+		}
+
+		vifs = append(vifs, vif)
+	}
+
+	sort.Slice(vifs, func(i, j int) bool {
+		return aws.StringValue(vifs[i].VirtualInterfaceId) < aws.StringValue(vifs[j].VirtualInterfaceId)
+	})
+
+	return vifs, nil
+}
+
+// dxVirtualInterfaceStateIsTerminal returns whether a virtual interface in the specified state no longer exists.
+func dxVirtualInterfaceStateIsTerminal(state string) bool {
+	return state == directconnect.VirtualInterfaceStateDeleted
+}
+
 func dxVirtualInterfaceRead(id string, conn *directconnect.DirectConnect) (*directconnect.VirtualInterface, error) {
 	resp, state, err := dxVirtualInterfaceStateRefresh(conn, id)()
 	if err != nil {
 		return nil, fmt.Errorf("error reading Direct Connect virtual interface (%s): %s", id, err)
 	}
-	if state == directconnect.VirtualInterfaceStateDeleted {
+	if dxVirtualInterfaceStateIsTerminal(state) {
 		return nil, nil
 	}
 
 	return resp.(*directconnect.VirtualInterface), nil
 }
 
+// dxVirtualInterfaceConnection returns the connection on which a virtual interface is provisioned, for the virtual interface's
+// computed connection attributes. The connection's attributes are informational, so if it cannot be found, e.g. for a LAG,
+// or cannot be read, e.g. by a role that may only describe virtual interfaces, an empty connection is returned.
+func dxVirtualInterfaceConnection(conn *directconnect.DirectConnect, vif *directconnect.VirtualInterface) *directconnect.Connection {
+	connectionId := aws.StringValue(vif.ConnectionId)
+
+	connection, err := dxConnectionLookup(conn, connectionId)
+	if err != nil {
+		log.Printf("[WARN] Unable to read Direct Connect virtual interface (%s) connection (%s): %s", aws.StringValue(vif.VirtualInterfaceId), connectionId, err)
+		return &directconnect.Connection{}
+	}
+	if connection == nil {
+		return &directconnect.Connection{}
+	}
+
+	return connection
+}
+
+// dxTransitVirtualInterfaceAmazonSideAsn returns the Amazon side ASN of a transit virtual interface.
+// The API may return no Amazon side ASN on the virtual interface itself, in which case that of its Direct Connect gateway is returned.
+// Zero is returned if the gateway cannot be read, e.g. because it is owned by another account.
+func dxTransitVirtualInterfaceAmazonSideAsn(conn *directconnect.DirectConnect, vif *directconnect.VirtualInterface) int64 {
+	if v := aws.Int64Value(vif.AmazonSideAsn); v != 0 {
+		return v
+	}
+
+	dxgwId := aws.StringValue(vif.DirectConnectGatewayId)
+	if dxgwId == "" {
+		return 0
+	}
+
+	amazonSideAsn, err := dxGatewayAmazonSideAsn(conn, dxgwId)
+	if err != nil {
+		log.Printf("[WARN] Unable to determine Direct Connect virtual interface (%s) Amazon side ASN: %s", aws.StringValue(vif.VirtualInterfaceId), err)
+		return 0
+	}
+
+	return amazonSideAsn
+}
+
+const (
+	dxConnectionLookupCacheTTL     = 30 * time.Second
+	dxConnectionLookupRetryTimeout = 1 * time.Minute
+)
+
+type dxConnectionLookupCacheKey struct {
+	conn         *directconnect.DirectConnect
+	connectionId string
+}
+
+type dxConnectionLookupCacheEntry struct {
+	connection *directconnect.Connection
+	expires    time.Time
+}
+
+// dxConnectionLookupCache caches connections described for the computed attributes of virtual interfaces
+// so that reading many virtual interfaces on the same connection describes it only once in a short period.
+var dxConnectionLookupCache = struct {
+	sync.Mutex
+	m map[dxConnectionLookupCacheKey]dxConnectionLookupCacheEntry
+}{m: make(map[dxConnectionLookupCacheKey]dxConnectionLookupCacheEntry)}
+
+// dxConnectionLookup returns the specified connection, retrying transient server errors.
+// Throttling is retried with backoff by the AWS SDK. nil is returned if the connection cannot be found.
+// All lookups of a connection made to compute attributes of a virtual interface should use this function.
+func dxConnectionLookup(conn *directconnect.DirectConnect, connectionId string) (*directconnect.Connection, error) {
+	key := dxConnectionLookupCacheKey{conn: conn, connectionId: connectionId}
+
+	// The lock is only held for map access so that a slow lookup of one connection does not block others.
+	// Concurrent lookups of the same uncached connection may each describe it.
+	dxConnectionLookupCache.Lock()
+	entry, ok := dxConnectionLookupCache.m[key]
+	dxConnectionLookupCache.Unlock()
+
+	if ok && time.Now().Before(entry.expires) {
+		return entry.connection, nil
+	}
+
+	input := &directconnect.DescribeConnectionsInput{
+		ConnectionId: aws.String(connectionId),
+	}
+
+	var resp *directconnect.Connections
+	err := resource.Retry(dxConnectionLookupRetryTimeout, func() *resource.RetryError {
+		var err error
+		resp, err = conn.DescribeConnections(input)
+		if isAWSErr(err, directconnect.ErrCodeServerException, "") {
+			return resource.RetryableError(err)
+		}
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		return nil
+	})
+	if isResourceTimeoutError(err) {
+		resp, err = conn.DescribeConnections(input)
+	}
+	if err != nil && !isNoSuchDxConnectionErr(err) {
+		return nil, fmt.Errorf("error reading Direct Connect connection (%s): %s", connectionId, err)
+	}
+
+	var connection *directconnect.Connection
+	if resp != nil {
+		for _, v := range resp.Connections {
+			if aws.StringValue(v.ConnectionId) == connectionId {
+				connection = v
+				break
+			}
+		}
+	}
+
+	// A connection that cannot be found is not cached as it may only be transiently missing.
+	if connection == nil {
+		return nil, nil
+	}
+
+	dxConnectionLookupCache.Lock()
+	dxConnectionLookupCache.m[key] = dxConnectionLookupCacheEntry{
+		connection: connection,
+		expires:    time.Now().Add(dxConnectionLookupCacheTTL),
+	}
+	dxConnectionLookupCache.Unlock()
+
+	return connection, nil
+}
+
 func dxVirtualInterfaceUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).dxconn
 
@@ -38,12 +623,21 @@ func dxVirtualInterfaceUpdate(d *schema.ResourceData, meta interface{}) error {
 		if err != nil {
 			return fmt.Errorf("error modifying Direct Connect virtual interface (%s) attributes: %s", d.Id(), err)
 		}
+
+		if err := dxVirtualInterfaceWaitUntilMtuConsistent(conn, d.Id(), d.Get("mtu").(int), dxVirtualInterfaceAttributesConsistencyTimeout); err != nil {
+			return err
+		}
 	}
 
 	arn := d.Get("arn").(string)
 	if d.HasChange("tags_all") {
 		o, n := d.GetChange("tags_all")
 
+		// Tags with ignored keys are managed outside of Terraform, so are neither removed nor changed.
+		ignoreTagKeys := keyvaluetags.New(d.Get("ignore_tag_keys").(*schema.Set).List())
+		o = keyvaluetags.New(o).Ignore(ignoreTagKeys).Map()
+		n = keyvaluetags.New(n).Ignore(ignoreTagKeys).Map()
+
 		if err := keyvaluetags.DirectconnectUpdateTags(conn, arn, o, n); err != nil {
 			return fmt.Errorf("error updating Direct Connect virtual interface (%s) tags: %s", arn, err)
 		}
@@ -52,13 +646,162 @@ func dxVirtualInterfaceUpdate(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// dxVirtualInterfaceAttributesConsistencyTimeout bounds how long to wait for an attribute update to be visible on read.
+const dxVirtualInterfaceAttributesConsistencyTimeout = 2 * time.Minute
+
+// dxVirtualInterfaceWaitUntilMtuConsistent re-reads the virtual interface until it reports the requested MTU.
+// Reads are eventually consistent after UpdateVirtualInterfaceAttributes, and a stale MTU would otherwise
+// produce a diff immediately after apply. If the timeout expires a warning is logged rather than failing the update.
+func dxVirtualInterfaceWaitUntilMtuConsistent(conn *directconnect.DirectConnect, vifId string, mtu int, timeout time.Duration) error {
+	var got int64
+	stale := false
+
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		stale = false
+
+		vif, err := dxVirtualInterfaceRead(vifId, conn)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if vif == nil {
+			return resource.NonRetryableError(fmt.Errorf("Direct Connect virtual interface (%s) not found", vifId))
+		}
+
+		got = aws.Int64Value(vif.Mtu)
+		if got != int64(mtu) {
+			stale = true
+			return resource.RetryableError(fmt.Errorf("Direct Connect virtual interface (%s) MTU is %d, expected %d", vifId, got, mtu))
+		}
+
+		return nil
+	})
+
+	if err != nil && (stale || isResourceTimeoutError(err)) {
+		log.Printf("[WARN] Direct Connect virtual interface (%s) MTU is still %d after %s, expected %d", vifId, got, timeout, mtu)
+		return nil
+	}
+
+	return err
+}
+
+// dxFilterSystemTags removes the tags that are not managed by Terraform from the tags read from a Direct Connect resource:
+// AWS-reserved "aws:" tags and those ignored by the provider's ignore_tags configuration.
+// It is used by the read path of every Direct Connect resource and data source that supports tags.
+func dxFilterSystemTags(tags keyvaluetags.KeyValueTags, ignoreTagsConfig *keyvaluetags.IgnoreConfig) keyvaluetags.KeyValueTags {
+	return tags.IgnoreAws().IgnoreConfig(ignoreTagsConfig)
+}
+
+// dxVirtualInterfaceDisplayNameTagKey is the key of the tag in which a virtual interface's "display_name" is stored.
+// Unlike the virtual interface's name, the tag can be changed without recreating the virtual interface.
+const dxVirtualInterfaceDisplayNameTagKey = "Name"
+
+// dxVirtualInterfaceDisplayNameCustomizeDiff ensures that the tag storing "display_name" is not also configured in "tags" or the provider's default tags.
+func dxVirtualInterfaceDisplayNameCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Get("display_name").(string) == "" {
+		return nil
+	}
+
+	// The tags are merged here, rather than read from "tags_all", as "tags_all" may not yet be known.
+	tags := meta.(*AWSClient).DefaultTagsConfig.MergeTags(keyvaluetags.New(diff.Get("tags").(map[string]interface{})))
+	if _, ok := tags.Map()[dxVirtualInterfaceDisplayNameTagKey]; ok {
+		return fmt.Errorf("'display_name' is stored in the %q tag, which must not also be configured in 'tags' or the provider's 'default_tags'", dxVirtualInterfaceDisplayNameTagKey)
+	}
+
+	return nil
+}
+
+// dxVirtualInterfaceDisplayNameTags returns the tag storing the specified display name.
+func dxVirtualInterfaceDisplayNameTags(displayName string) keyvaluetags.KeyValueTags {
+	if displayName == "" {
+		return keyvaluetags.New(map[string]string{})
+	}
+
+	return keyvaluetags.New(map[string]string{dxVirtualInterfaceDisplayNameTagKey: displayName})
+}
+
+// dxVirtualInterfaceFlattenDisplayName sets "display_name" from the virtual interface's tags, if it is managed,
+// and returns the remaining tags.
+func dxVirtualInterfaceFlattenDisplayName(d *schema.ResourceData, tags keyvaluetags.KeyValueTags) keyvaluetags.KeyValueTags {
+	if d.Get("display_name").(string) == "" {
+		return tags
+	}
+
+	d.Set("display_name", aws.StringValue(tags.KeyValue(dxVirtualInterfaceDisplayNameTagKey)))
+
+	return tags.Ignore(keyvaluetags.New([]string{dxVirtualInterfaceDisplayNameTagKey}))
+}
+
+// dxVirtualInterfaceUpdateDisplayName updates the tag storing the virtual interface's "display_name".
+func dxVirtualInterfaceUpdateDisplayName(d *schema.ResourceData, meta interface{}) error {
+	if !d.HasChange("display_name") {
+		return nil
+	}
+
+	conn := meta.(*AWSClient).dxconn
+	arn := d.Get("arn").(string)
+	o, n := d.GetChange("display_name")
+
+	if err := keyvaluetags.DirectconnectUpdateTags(conn, arn, dxVirtualInterfaceDisplayNameTags(o.(string)), dxVirtualInterfaceDisplayNameTags(n.(string))); err != nil {
+		return fmt.Errorf("error updating Direct Connect virtual interface (%s) display name: %s", arn, err)
+	}
+
+	return nil
+}
+
+// dxHostedVirtualInterfaceAccepterManagedTags returns only those tags whose keys are managed by a hosted virtual interface accepter.
+// Tags applied by the virtual interface's creator are therefore neither read into the accepter's state nor removed by it.
+func dxHostedVirtualInterfaceAccepterManagedTags(d *schema.ResourceData, tags keyvaluetags.KeyValueTags) keyvaluetags.KeyValueTags {
+	return tags.Only(keyvaluetags.New(d.Get("managed_tag_keys").(*schema.Set).List()))
+}
+
+// dxHostedVirtualInterfaceAccepterManagedTagKeysCustomizeDiff plans "managed_tag_keys" as the keys of "tags_all",
+// so that the keys managed by a hosted virtual interface accepter are known before apply.
+func dxHostedVirtualInterfaceAccepterManagedTagKeysCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("tags_all") {
+		return diff.SetNewComputed("managed_tag_keys")
+	}
+
+	keys := keyvaluetags.New(diff.Get("tags_all").(map[string]interface{})).Keys()
+	if diff.Get("managed_tag_keys").(*schema.Set).Equal(flattenStringSet(aws.StringSlice(keys))) {
+		return nil
+	}
+
+	return diff.SetNew("managed_tag_keys", keys)
+}
+
 func dxVirtualInterfaceDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).dxconn
 
+	vifRaw, state, err := dxVirtualInterfaceStateRefresh(conn, d.Id())()
+	if isAWSErr(err, directconnect.ErrCodeClientException, "does not exist") {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading Direct Connect virtual interface (%s): %s", d.Id(), err)
+	}
+	if dxVirtualInterfaceStateIsTerminal(state) {
+		log.Printf("[DEBUG] Direct Connect virtual interface (%s) already deleted", d.Id())
+		return nil
+	}
+
+	if dxVirtualInterfaceBgpUp(vifRaw.(*directconnect.VirtualInterface)) {
+		if d.Get("drain_before_delete").(bool) {
+			if err := dxVirtualInterfaceDrain(conn, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+				return err
+			}
+		} else if d.Get("prevent_active_delete").(bool) {
+			return fmt.Errorf("error deleting Direct Connect virtual interface (%s): BGP is up and prevent_active_delete is set", d.Id())
+		} else {
+			log.Printf("[WARN] Direct Connect virtual interface (%s) has BGP up, deleting it will drop traffic", d.Id())
+		}
+	}
+
+	if d.Get("warn_if_last_on_connection").(bool) {
+		dxVirtualInterfaceWarnIfLastOnConnection(conn, vifRaw.(*directconnect.VirtualInterface))
+	}
+
 	log.Printf("[DEBUG] Deleting Direct Connect virtual interface: %s", d.Id())
-	_, err := conn.DeleteVirtualInterface(&directconnect.DeleteVirtualInterfaceInput{
-		VirtualInterfaceId: aws.String(d.Id()),
-	})
+	err = dxVirtualInterfaceDeleteWhenDeletable(conn, d.Id(), d.Timeout(schema.TimeoutDelete))
 	if err != nil {
 		if isAWSErr(err, directconnect.ErrCodeClientException, "does not exist") {
 			return nil
@@ -66,6 +809,11 @@ func dxVirtualInterfaceDelete(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("error deleting Direct Connect virtual interface (%s): %s", d.Id(), err)
 	}
 
+	if d.Get("skip_delete_wait").(bool) {
+		log.Printf("[WARN] Not waiting for Direct Connect virtual interface (%s) to be deleted", d.Id())
+		return nil
+	}
+
 	deleteStateConf := &resource.StateChangeConf{
 		Pending: []string{
 			directconnect.VirtualInterfaceStateAvailable,
@@ -81,8 +829,8 @@ func dxVirtualInterfaceDelete(d *schema.ResourceData, meta interface{}) error {
 		},
 		Refresh:    dxVirtualInterfaceStateRefresh(conn, d.Id()),
 		Timeout:    d.Timeout(schema.TimeoutDelete),
-		Delay:      10 * time.Second,
-		MinTimeout: 5 * time.Second,
+		Delay:      dxJitter(10 * time.Second),
+		MinTimeout: dxJitter(5 * time.Second),
 	}
 	_, err = deleteStateConf.WaitForState()
 	if err != nil {
@@ -92,11 +840,218 @@ func dxVirtualInterfaceDelete(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// dxVirtualInterfaceWarnIfLastOnConnection logs a warning if the virtual interface is the last one on its connection or LAG,
+// as deleting it leaves the connection idle. The check is advisory only, so errors are logged rather than returned.
+func dxVirtualInterfaceWarnIfLastOnConnection(conn *directconnect.DirectConnect, vif *directconnect.VirtualInterface) {
+	vifId := aws.StringValue(vif.VirtualInterfaceId)
+	connectionId := aws.StringValue(vif.ConnectionId)
+
+	vifs, err := dxConnectionVirtualInterfaces(conn, connectionId)
+	if err != nil {
+		log.Printf("[WARN] Unable to check for other virtual interfaces on Direct Connect connection (%s): %s", connectionId, err)
+		return
+	}
+
+	for _, v := range vifs {
+		if aws.StringValue(v.VirtualInterfaceId) != vifId {
+			return
+		}
+	}
+
+	log.Printf("[WARN] Direct Connect virtual interface (%s) is the last virtual interface on connection (%s), which will be left with no virtual interfaces", vifId, connectionId)
+}
+
+// dxVirtualInterfaceDeleteWhenDeletable deletes the virtual interface, retrying within the timeout
+// if it is still transitioning, e.g. "pending" creation, until it reaches a deletable state.
+func dxVirtualInterfaceDeleteWhenDeletable(conn *directconnect.DirectConnect, vifId string, timeout time.Duration) error {
+	input := &directconnect.DeleteVirtualInterfaceInput{
+		VirtualInterfaceId: aws.String(vifId),
+	}
+
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		_, err := conn.DeleteVirtualInterface(input)
+		if isAWSErr(err, directconnect.ErrCodeClientException, "transitioning state") {
+			log.Printf("[DEBUG] Direct Connect virtual interface (%s) is transitioning, waiting for a deletable state", vifId)
+			stateConf := &resource.StateChangeConf{
+				Pending: []string{
+					directconnect.VirtualInterfaceStatePending,
+				},
+				Target: []string{
+					directconnect.VirtualInterfaceStateAvailable,
+					directconnect.VirtualInterfaceStateConfirming,
+					directconnect.VirtualInterfaceStateDown,
+					directconnect.VirtualInterfaceStateRejected,
+					directconnect.VirtualInterfaceStateVerifying,
+				},
+				Refresh:    dxVirtualInterfaceStateRefresh(conn, vifId),
+				Timeout:    timeout,
+				MinTimeout: dxJitter(5 * time.Second),
+			}
+			if _, err := stateConf.WaitForState(); err != nil {
+				return resource.NonRetryableError(err)
+			}
+
+			return resource.RetryableError(err)
+		}
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		return nil
+	})
+
+	if isResourceTimeoutError(err) {
+		_, err = conn.DeleteVirtualInterface(input)
+	}
+
+	return err
+}
+
+// dxVirtualInterfaceConfirmWhenConfirmable calls confirm to accept a hosted virtual interface, retrying within the timeout
+// while the virtual interface has not yet reached the "confirming" state. The virtual interface is created in another account
+// and may not yet be visible, or still be pending, when the accepter is created in the same run as the creator.
+func dxVirtualInterfaceConfirmWhenConfirmable(conn *directconnect.DirectConnect, vifId string, timeout time.Duration, confirm func() error) error {
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		err := confirm()
+		if isAWSErr(err, directconnect.ErrCodeClientException, "") {
+			vif, state, readErr := dxVirtualInterfaceStateRefresh(conn, vifId)()
+			if readErr != nil {
+				return resource.NonRetryableError(err)
+			}
+
+			// A virtual interface that is not yet visible is reported as "deleted", but one that has actually been deleted,
+			// like one that has been rejected, will never become confirmable. One that is "confirming" can be confirmed,
+			// so any error then is not retried either.
+			if _, ok := vif.(*directconnect.VirtualInterface); !ok {
+				log.Printf("[DEBUG] Direct Connect virtual interface (%s) not found, waiting for it to be confirmable: %s", vifId, err)
+				return resource.RetryableError(err)
+			}
+			if state == directconnect.VirtualInterfaceStatePending {
+				log.Printf("[DEBUG] Direct Connect virtual interface (%s) is %s, waiting for it to be confirmable: %s", vifId, state, err)
+				return resource.RetryableError(err)
+			}
+		}
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		return nil
+	})
+
+	if isResourceTimeoutError(err) {
+		err = confirm()
+	}
+
+	return err
+}
+
+// flattenDxVirtualInterfaceCloudWatchDimensions returns the CloudWatch metric dimensions identifying a virtual interface.
+func flattenDxVirtualInterfaceCloudWatchDimensions(vif *directconnect.VirtualInterface) map[string]interface{} {
+	return map[string]interface{}{
+		"ConnectionId":       aws.StringValue(vif.ConnectionId),
+		"VirtualInterfaceId": aws.StringValue(vif.VirtualInterfaceId),
+	}
+}
+
+const (
+	dxBgpAuthKeyMinLength = 6
+	dxBgpAuthKeyMaxLength = 80
+
+	dxBgpAuthKeyDefaultCharset = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+// validateDxBgpAuthKeyCharset validates that a BGP authentication key character set contains no whitespace.
+var validateDxBgpAuthKeyCharset = validation.StringMatch(regexp.MustCompile(`^[[:graph:]]+$`), "must contain only printable, non-whitespace ASCII characters")
+
+// dxVirtualInterfaceBgpAuthKey returns the configured BGP authentication key or,
+// if 'bgp_auth_key_length' is configured, a key generated from 'bgp_auth_key_charset'.
+// An empty key leaves it to AWS to generate one.
+func dxVirtualInterfaceBgpAuthKey(d *schema.ResourceData) (string, error) {
+	if v, ok := d.GetOk("bgp_auth_key"); ok {
+		return v.(string), nil
+	}
+
+	length := d.Get("bgp_auth_key_length").(int)
+	if length == 0 {
+		return "", nil
+	}
+
+	charset := dxBgpAuthKeyDefaultCharset
+	if v, ok := d.GetOk("bgp_auth_key_charset"); ok {
+		charset = v.(string)
+	}
+
+	key, err := dxGenerateBgpAuthKey(length, charset)
+	if err != nil {
+		return "", fmt.Errorf("error generating BGP authentication key: %w", err)
+	}
+
+	return key, nil
+}
+
+// dxGenerateBgpAuthKey returns a cryptographically random key of the specified length drawn from the charset.
+func dxGenerateBgpAuthKey(length int, charset string) (string, error) {
+	if charset == "" {
+		return "", fmt.Errorf("empty character set")
+	}
+
+	result := make([]byte, length)
+	charsetSize := big.NewInt(int64(len(charset)))
+
+	for i := range result {
+		r, err := crand.Int(crand.Reader, charsetSize)
+		if err != nil {
+			return "", err
+		}
+
+		result[i] = charset[r.Int64()]
+	}
+
+	return string(result), nil
+}
+
+// flattenDxVirtualInterfaceBgpPeerIds returns the IDs of the virtual interface's BGP peers.
+func flattenDxVirtualInterfaceBgpPeerIds(vif *directconnect.VirtualInterface) []string {
+	ids := []string{}
+
+	for _, bgpPeer := range vif.BgpPeers {
+		if bgpPeer == nil {
+			continue
+		}
+
+		if id := aws.StringValue(bgpPeer.BgpPeerId); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// dxVirtualInterfaceBgpUp returns whether any of the virtual interface's BGP peers has BGP up.
+func dxVirtualInterfaceBgpUp(vif *directconnect.VirtualInterface) bool {
+	for _, bgpPeer := range vif.BgpPeers {
+		if aws.StringValue(bgpPeer.BgpStatus) == directconnect.BgpStatusUp {
+			return true
+		}
+	}
+
+	return false
+}
+
+// dxVirtualInterfaceStateRefresh returns a StateRefreshFunc for the virtual interface.
+// Once a state has been seen, transient server and throttling errors return the last known state
+// so that a waiter keeps polling instead of failing.
 func dxVirtualInterfaceStateRefresh(conn *directconnect.DirectConnect, vifId string) resource.StateRefreshFunc {
+	var lastVif *directconnect.VirtualInterface
+
 	return func() (interface{}, string, error) {
 		resp, err := conn.DescribeVirtualInterfaces(&directconnect.DescribeVirtualInterfacesInput{
 			VirtualInterfaceId: aws.String(vifId),
 		})
+		if lastVif != nil && isDxTransientErr(err) {
+			log.Printf("[WARN] Error reading Direct Connect virtual interface (%s), retrying: %s", vifId, err)
+			return lastVif, aws.StringValue(lastVif.VirtualInterfaceState), nil
+		}
 		if err != nil {
 			return nil, "", err
 		}
@@ -108,6 +1063,7 @@ func dxVirtualInterfaceStateRefresh(conn *directconnect.DirectConnect, vifId str
 
 		case 1:
 			vif := resp.VirtualInterfaces[0]
+			lastVif = vif
 			return vif, aws.StringValue(vif.VirtualInterfaceState), nil
 
 		default:
@@ -116,14 +1072,41 @@ func dxVirtualInterfaceStateRefresh(conn *directconnect.DirectConnect, vifId str
 	}
 }
 
+// dxVirtualInterfaceNotFoundChecks is the number of consecutive polls for which a virtual interface
+// may be missing while waiting for it to become available.
+const dxVirtualInterfaceNotFoundChecks = 5
+
+// dxVirtualInterfaceAvailableDelay and dxVirtualInterfaceAvailableMinTimeout are the delay before the first poll, and the minimum
+// interval between polls, while waiting for a virtual interface to become available. Unit tests shorten them.
+var (
+	dxVirtualInterfaceAvailableDelay      = 10 * time.Second
+	dxVirtualInterfaceAvailableMinTimeout = 5 * time.Second
+)
+
 func dxVirtualInterfaceWaitUntilAvailable(conn *directconnect.DirectConnect, vifId string, timeout time.Duration, pending, target []string) error {
+	refresh := dxVirtualInterfaceStateRefresh(conn, vifId)
 	stateConf := &resource.StateChangeConf{
-		Pending:    pending,
-		Target:     target,
-		Refresh:    dxVirtualInterfaceStateRefresh(conn, vifId),
-		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 5 * time.Second,
+		Pending: pending,
+		Target:  target,
+		Refresh: func() (interface{}, string, error) {
+			vifRaw, state, err := refresh()
+			if err != nil {
+				return nil, "", err
+			}
+
+			// A newly created virtual interface may be transiently missing from DescribeVirtualInterfaces.
+			// Report it as not found so that the waiter retries up to NotFoundChecks times before failing.
+			if _, ok := vifRaw.(*directconnect.VirtualInterface); !ok {
+				log.Printf("[DEBUG] Direct Connect virtual interface (%s) not found", vifId)
+				return nil, "", nil
+			}
+
+			return vifRaw, state, nil
+		},
+		Timeout:        timeout,
+		Delay:          dxJitter(dxVirtualInterfaceAvailableDelay),
+		MinTimeout:     dxJitter(dxVirtualInterfaceAvailableMinTimeout),
+		NotFoundChecks: dxVirtualInterfaceNotFoundChecks,
 	}
 	if _, err := stateConf.WaitForState(); err != nil {
 		return fmt.Errorf("error waiting for Direct Connect virtual interface (%s) to become available: %s", vifId, err)
@@ -131,3 +1114,306 @@ func dxVirtualInterfaceWaitUntilAvailable(conn *directconnect.DirectConnect, vif
 
 	return nil
 }
+
+// isDxTransientErr returns whether the error is a Direct Connect server or throttling error that may succeed on retry.
+func isDxTransientErr(err error) bool {
+	return isAWSErr(err, directconnect.ErrCodeServerException, "") || isAWSErr(err, "ThrottlingException", "")
+}
+
+// dxVirtualInterfaceWaitUntilBgpUp waits until BGP is up on at least one of the virtual interface's BGP peers.
+func dxVirtualInterfaceWaitUntilBgpUp(conn *directconnect.DirectConnect, vifId string, timeout time.Duration) error {
+	refresh := dxVirtualInterfaceStateRefresh(conn, vifId)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{directconnect.BgpStatusDown},
+		Target:  []string{directconnect.BgpStatusUp},
+		Refresh: func() (interface{}, string, error) {
+			vifRaw, state, err := refresh()
+			if err != nil {
+				return nil, "", err
+			}
+			if dxVirtualInterfaceStateIsTerminal(state) {
+				return nil, "", fmt.Errorf("virtual interface deleted")
+			}
+
+			vif := vifRaw.(*directconnect.VirtualInterface)
+			if dxVirtualInterfaceBgpUp(vif) {
+				return vif, directconnect.BgpStatusUp, nil
+			}
+
+			return vif, directconnect.BgpStatusDown, nil
+		},
+		Timeout:    timeout,
+		Delay:      dxJitter(10 * time.Second),
+		MinTimeout: dxJitter(5 * time.Second),
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("error waiting for Direct Connect virtual interface (%s) BGP to be up: %s", vifId, err)
+	}
+
+	return nil
+}
+
+// dxVirtualInterfaceDrain forces BGP down on all of the virtual interface's BGP peers by starting a BGP failover test
+// and waits until BGP is down on all of them, so that the routes are withdrawn before the virtual interface is deleted.
+// This is a forced shutdown rather than a graceful drain, but Direct Connect has no other means of administratively
+// shutting down BGP on a virtual interface.
+func dxVirtualInterfaceDrain(conn *directconnect.DirectConnect, vifId string, timeout time.Duration) error {
+	log.Printf("[DEBUG] Draining Direct Connect virtual interface (%s) by starting a BGP failover test", vifId)
+	_, err := conn.StartBgpFailoverTest(&directconnect.StartBgpFailoverTestInput{
+		VirtualInterfaceId: aws.String(vifId),
+	})
+	if err != nil {
+		return fmt.Errorf("error starting BGP failover test to drain Direct Connect virtual interface (%s): %s", vifId, err)
+	}
+
+	refresh := dxVirtualInterfaceStateRefresh(conn, vifId)
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{directconnect.BgpStatusUp},
+		Target:  []string{directconnect.BgpStatusDown},
+		Refresh: func() (interface{}, string, error) {
+			vifRaw, state, err := refresh()
+			if err != nil {
+				return nil, "", err
+			}
+			if dxVirtualInterfaceStateIsTerminal(state) {
+				return nil, "", fmt.Errorf("virtual interface deleted")
+			}
+
+			vif := vifRaw.(*directconnect.VirtualInterface)
+			if dxVirtualInterfaceBgpUp(vif) {
+				return vif, directconnect.BgpStatusUp, nil
+			}
+
+			return vif, directconnect.BgpStatusDown, nil
+		},
+		Timeout:    timeout,
+		MinTimeout: dxJitter(5 * time.Second),
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("error waiting for Direct Connect virtual interface (%s) BGP to be down: %s", vifId, err)
+	}
+
+	return nil
+}
+
+// dxVirtualInterfaceCheckVlanAvailable returns an error if the specified VLAN is already in use
+// by a virtual interface, other than the specified one, on the connection or LAG.
+func dxVirtualInterfaceCheckVlanAvailable(conn *directconnect.DirectConnect, connectionId string, vlan int, excludeVifId string) error {
+	vifId, err := dxConnectionVlanInUseBy(conn, connectionId, vlan, excludeVifId)
+	if err != nil {
+		return err
+	}
+
+	if vifId != "" {
+		return fmt.Errorf("VLAN %d is already in use on Direct Connect connection (%s) by virtual interface (%s)", vlan, connectionId, vifId)
+	}
+
+	return nil
+}
+
+// dxVirtualInterfaceVlanCustomizeDiff ensures that the VLAN of a new virtual interface is not already in use on its connection.
+// Providers cannot see the plans of other resources, so two new virtual interfaces with the same VLAN are not detected at plan time.
+func dxVirtualInterfaceVlanCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() != "" && !diff.HasChange("vlan") && !diff.HasChange("connection_id") {
+		return nil
+	}
+
+	if !diff.NewValueKnown("vlan") || !diff.NewValueKnown("connection_id") {
+		return nil
+	}
+
+	return dxVirtualInterfaceCheckVlanAvailable(meta.(*AWSClient).dxconn, diff.Get("connection_id").(string), diff.Get("vlan").(int), diff.Id())
+}
+
+// dxConnectionVlanInUseBy returns the ID of the virtual interface, other than the specified one, that uses the VLAN on the connection or LAG.
+// An empty string is returned if the VLAN is not in use.
+func dxConnectionVlanInUseBy(conn *directconnect.DirectConnect, connectionId string, vlan int, excludeVifId string) (string, error) {
+	vifs, err := dxConnectionVirtualInterfaces(conn, connectionId)
+	if err != nil {
+		return "", err
+	}
+
+	for _, vif := range vifs {
+		if vifId := aws.StringValue(vif.VirtualInterfaceId); vifId != excludeVifId && int(aws.Int64Value(vif.Vlan)) == vlan {
+			return vifId, nil
+		}
+	}
+
+	return "", nil
+}
+
+// dxVirtualInterfaceIgnoreTagKeysCustomizeDiff ensures that no key in "ignore_tag_keys" is also managed in "tags_all",
+// as an ignored tag is never read back and so would always differ. It must follow SetTagsDiff.
+func dxVirtualInterfaceIgnoreTagKeysCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("tags_all") || !diff.NewValueKnown("ignore_tag_keys") {
+		return nil
+	}
+
+	ignoreTagKeys := keyvaluetags.New(diff.Get("ignore_tag_keys").(*schema.Set).List())
+	if keys := keyvaluetags.New(diff.Get("tags_all").(map[string]interface{})).Only(ignoreTagKeys).Keys(); len(keys) > 0 {
+		sort.Strings(keys)
+		return fmt.Errorf("tags %s are in both 'tags', or the provider's default tags, and 'ignore_tag_keys'", strings.Join(keys, ", "))
+	}
+
+	return nil
+}
+
+// dxVirtualInterfaceRequiredTagsCustomizeDiff ensures that all of the "required_tag_keys" are present in "tags_all".
+// It must follow SetTagsDiff so that the provider's default tags have been merged into "tags_all".
+func dxVirtualInterfaceRequiredTagsCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	requiredTagKeys := expandStringSet(diff.Get("required_tag_keys").(*schema.Set))
+	if len(requiredTagKeys) == 0 || !diff.NewValueKnown("tags_all") {
+		return nil
+	}
+
+	tags := keyvaluetags.New(diff.Get("tags_all").(map[string]interface{}))
+	if missing := dxMissingTagKeys(tags, aws.StringValueSlice(requiredTagKeys)); len(missing) > 0 {
+		return fmt.Errorf("Direct Connect virtual interface is missing required tags: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// dxMissingTagKeys returns the sorted keys that are not present in the tags.
+func dxMissingTagKeys(tags keyvaluetags.KeyValueTags, keys []string) []string {
+	var missing []string
+
+	for _, key := range keys {
+		if !tags.KeyExists(key) {
+			missing = append(missing, key)
+		}
+	}
+
+	sort.Strings(missing)
+
+	return missing
+}
+
+// dxVirtualInterfaceJumboFrameCustomizeDiff ensures that a jumbo frame MTU of 9001 is only requested
+// on a connection or LAG that supports jumbo frames.
+func dxVirtualInterfaceJumboFrameCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.HasChange("mtu") || diff.Get("mtu").(int) != 9001 || !diff.NewValueKnown("connection_id") {
+		return nil
+	}
+
+	connectionId := diff.Get("connection_id").(string)
+	jumboFrameCapable, err := dxConnectionJumboFrameCapable(meta.(*AWSClient).dxconn, connectionId)
+	if err != nil {
+		return err
+	}
+
+	if jumboFrameCapable != nil && !aws.BoolValue(jumboFrameCapable) {
+		return fmt.Errorf("'mtu' 9001 requires jumbo frames but Direct Connect connection (%s) is not jumbo frame capable", connectionId)
+	}
+
+	return nil
+}
+
+type dxLagJumboFrameCapableCacheEntry struct {
+	jumboFrameCapable *bool
+	expires           time.Time
+}
+
+// dxLagJumboFrameCapableCache caches whether LAGs are jumbo frame capable so that planning many virtual interfaces
+// on the same LAG describes it only once in a short period. Connections are cached by dxConnectionLookup.
+var dxLagJumboFrameCapableCache = struct {
+	sync.Mutex
+	m map[dxConnectionLookupCacheKey]dxLagJumboFrameCapableCacheEntry
+}{m: make(map[dxConnectionLookupCacheKey]dxLagJumboFrameCapableCacheEntry)}
+
+// dxConnectionJumboFrameCapable returns whether the specified connection or LAG is jumbo frame capable.
+// nil is returned if the connection or LAG cannot be found.
+func dxConnectionJumboFrameCapable(conn *directconnect.DirectConnect, connectionId string) (*bool, error) {
+	if strings.HasPrefix(connectionId, "dxlag-") {
+		return dxLagJumboFrameCapable(conn, connectionId)
+	}
+
+	connection, err := dxConnectionLookup(conn, connectionId)
+	if err != nil {
+		return nil, err
+	}
+	if connection == nil {
+		return nil, nil
+	}
+
+	return connection.JumboFrameCapable, nil
+}
+
+// dxLagJumboFrameCapable returns whether the specified LAG is jumbo frame capable.
+// nil is returned if the LAG cannot be found.
+func dxLagJumboFrameCapable(conn *directconnect.DirectConnect, lagId string) (*bool, error) {
+	key := dxConnectionLookupCacheKey{conn: conn, connectionId: lagId}
+
+	dxLagJumboFrameCapableCache.Lock()
+	entry, ok := dxLagJumboFrameCapableCache.m[key]
+	dxLagJumboFrameCapableCache.Unlock()
+
+	if ok && time.Now().Before(entry.expires) {
+		return entry.jumboFrameCapable, nil
+	}
+
+	resp, err := conn.DescribeLags(&directconnect.DescribeLagsInput{
+		LagId: aws.String(lagId),
+	})
+	if isNoSuchDxLagErr(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading Direct Connect LAG (%s): %s", lagId, err)
+	}
+
+	// A LAG that cannot be found is not cached as it may only be transiently missing.
+	if len(resp.Lags) != 1 {
+		return nil, nil
+	}
+
+	jumboFrameCapable := resp.Lags[0].JumboFrameCapable
+
+	dxLagJumboFrameCapableCache.Lock()
+	dxLagJumboFrameCapableCache.m[key] = dxLagJumboFrameCapableCacheEntry{
+		jumboFrameCapable: jumboFrameCapable,
+		expires:           time.Now().Add(dxConnectionLookupCacheTTL),
+	}
+	dxLagJumboFrameCapableCache.Unlock()
+
+	return jumboFrameCapable, nil
+}
+
+// dxVirtualInterfaceWaitUntilConnectionAvailable waits until the connection or LAG on which a virtual interface is to be created is available or down.
+func dxVirtualInterfaceWaitUntilConnectionAvailable(conn *directconnect.DirectConnect, connectionId string, timeout time.Duration) error {
+	refresh := dxConnectionRefreshStateFunc(conn, connectionId)
+	if strings.HasPrefix(connectionId, "dxlag-") {
+		refresh = dxLagRefreshStateFunc(conn, connectionId)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			directconnect.ConnectionStateOrdering,
+			directconnect.ConnectionStatePending,
+			directconnect.ConnectionStateRequested,
+		},
+		// A connection whose cross-connect is not yet in place is down, but virtual interfaces can already be created on it.
+		Target: []string{
+			directconnect.ConnectionStateAvailable,
+			directconnect.ConnectionStateDown,
+		},
+		Refresh:    refresh,
+		Timeout:    timeout,
+		Delay:      dxJitter(10 * time.Second),
+		MinTimeout: dxJitter(5 * time.Second),
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("error waiting for Direct Connect connection (%s) to become available: %s", connectionId, err)
+	}
+
+	return nil
+}
+
+// dxJitter returns the specified duration plus a random jitter of up to half that duration.
+// This spreads out the polling of many concurrent waiters to avoid API throttling.
+// A local source is used as the global one is deterministic unless seeded.
+func dxJitter(d time.Duration) time.Duration {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	return d + time.Duration(r.Int63n(int64(d)/2+1))
+}
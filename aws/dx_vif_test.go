@@ -2,13 +2,1248 @@ package aws
 
 import (
 	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
 )
 
+func init() {
+	resource.AddTestSweepers("aws_dx_virtual_interface", &resource.Sweeper{
+		Name: "aws_dx_virtual_interface",
+		F:    testSweepDxVirtualInterfaces,
+	})
+}
+
+func testSweepDxVirtualInterfaces(region string) error {
+	client, err := sharedClientForRegion(region)
+
+	if err != nil {
+		return fmt.Errorf("error getting client: %w", err)
+	}
+
+	conn := client.(*AWSClient).dxconn
+
+	var sweeperErrs *multierror.Error
+
+	vifs, err := dxListAllVirtualInterfaces(conn, "", "")
+
+	if testSweepSkipSweepError(err) {
+		log.Printf("[WARN] Skipping Direct Connect Virtual Interface sweep for %s: %s", region, err)
+		return sweeperErrs.ErrorOrNil()
+	}
+
+	if err != nil {
+		sweeperErr := fmt.Errorf("error listing Direct Connect Virtual Interfaces for %s: %w", region, err)
+		log.Printf("[ERROR] %s", sweeperErr)
+		sweeperErrs = multierror.Append(sweeperErrs, sweeperErr)
+		return sweeperErrs.ErrorOrNil()
+	}
+
+	for _, vif := range vifs {
+		id := aws.StringValue(vif.VirtualInterfaceId)
+
+		// Acceptance tests run against pre-existing connections, so only sweep virtual interfaces they created.
+		if !strings.HasPrefix(aws.StringValue(vif.VirtualInterfaceName), "tf-acc-test") {
+			log.Printf("[INFO] Skipping Direct Connect Virtual Interface (%s)", id)
+			continue
+		}
+
+		if dxVirtualInterfaceStateIsTerminal(aws.StringValue(vif.VirtualInterfaceState)) {
+			continue
+		}
+
+		// Deletion is the same for all types of virtual interface.
+		r := resourceAwsDxPrivateVirtualInterface()
+		d := r.Data(nil)
+		d.SetId(id)
+
+		err = r.Delete(d, client)
+
+		if err != nil {
+			sweeperErr := fmt.Errorf("error deleting Direct Connect Virtual Interface (%s): %w", id, err)
+			log.Printf("[ERROR] %s", sweeperErr)
+			sweeperErrs = multierror.Append(sweeperErrs, sweeperErr)
+			continue
+		}
+	}
+
+	return sweeperErrs.ErrorOrNil()
+}
+
+func TestDxListAllVirtualInterfaces(t *testing.T) {
+	var inputs []*directconnect.DescribeVirtualInterfacesInput
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			inputs = append(inputs, r.Params.(*directconnect.DescribeVirtualInterfacesInput))
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{
+				{VirtualInterfaceId: aws.String("dxvif-00000001"), VirtualInterfaceType: aws.String("private")},
+				nil,
+				{VirtualInterfaceId: aws.String("dxvif-00000002"), VirtualInterfaceType: aws.String("transit")},
+				{VirtualInterfaceId: aws.String("dxvif-00000003"), VirtualInterfaceType: aws.String("private")},
+			}
+		}
+	})
+
+	testCases := []struct {
+		ConnectionId string
+		VifType      string
+		Expected     []string
+	}{
+		{Expected: []string{"dxvif-00000001", "dxvif-00000002", "dxvif-00000003"}},
+		{ConnectionId: "dxcon-12345678", VifType: "private", Expected: []string{"dxvif-00000001", "dxvif-00000003"}},
+		{VifType: "public", Expected: nil},
+	}
+
+	for _, testCase := range testCases {
+		vifs, err := dxListAllVirtualInterfaces(conn, testCase.ConnectionId, testCase.VifType)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		var got []string
+		for _, vif := range vifs {
+			got = append(got, aws.StringValue(vif.VirtualInterfaceId))
+		}
+
+		if !reflect.DeepEqual(got, testCase.Expected) {
+			t.Errorf("connection %q, type %q: got %v, expected %v", testCase.ConnectionId, testCase.VifType, got, testCase.Expected)
+		}
+
+		if got, expected := aws.StringValue(inputs[len(inputs)-1].ConnectionId), testCase.ConnectionId; got != expected {
+			t.Errorf("got ConnectionId %q in request, expected %q", got, expected)
+		}
+	}
+}
+
+func TestDxVirtualInterfaceDelete_alreadyDeleted(t *testing.T) {
+	var operations []string
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		operations = append(operations, r.Operation.Name)
+
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateDeleted),
+			}}
+		}
+	})
+
+	d := resourceAwsDxPrivateVirtualInterface().Data(nil)
+	d.SetId("dxvif-12345678")
+
+	if err := dxVirtualInterfaceDelete(d, &AWSClient{dxconn: conn}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(operations) != 1 || operations[0] != "DescribeVirtualInterfaces" {
+		t.Errorf("expected only DescribeVirtualInterfaces to be called, got %v", operations)
+	}
+}
+
+func TestDxVirtualInterfaceDeleteWhenDeletable_pending(t *testing.T) {
+	var deleteCalls int
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DeleteVirtualInterfaceOutput:
+			deleteCalls++
+			if deleteCalls == 1 {
+				// Creation has not yet completed.
+				r.Error = awserr.New(directconnect.ErrCodeClientException, "Virtual interface dxvif-12345678 is in a transitioning state.", nil)
+				return
+			}
+			data.VirtualInterfaceState = aws.String(directconnect.VirtualInterfaceStateDeleting)
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+			}}
+		}
+	})
+
+	if err := dxVirtualInterfaceDeleteWhenDeletable(conn, "dxvif-12345678", 1*time.Minute); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if deleteCalls != 2 {
+		t.Errorf("expected DeleteVirtualInterface to be called 2 times, got %d", deleteCalls)
+	}
+}
+
+func TestDxVirtualInterfaceDelete_preventActiveDelete(t *testing.T) {
+	var operations []string
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		operations = append(operations, r.Operation.Name)
+
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				BgpPeers: []*directconnect.BGPPeer{{
+					BgpStatus: aws.String(directconnect.BgpStatusUp),
+				}},
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+			}}
+		}
+	})
+
+	d := resourceAwsDxPrivateVirtualInterface().Data(nil)
+	d.SetId("dxvif-12345678")
+	d.Set("prevent_active_delete", true)
+
+	if err := dxVirtualInterfaceDelete(d, &AWSClient{dxconn: conn}); err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	if len(operations) != 1 || operations[0] != "DescribeVirtualInterfaces" {
+		t.Errorf("expected only DescribeVirtualInterfaces to be called, got %v", operations)
+	}
+}
+
+func TestDxVirtualInterfaceConfirmWhenConfirmable_notReady(t *testing.T) {
+	var operations []string
+	state := directconnect.VirtualInterfaceStatePending
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		operations = append(operations, r.Operation.Name)
+
+		switch data := r.Data.(type) {
+		case *directconnect.ConfirmPrivateVirtualInterfaceOutput:
+			if state != directconnect.VirtualInterfaceStateConfirming {
+				r.Error = awserr.New(directconnect.ErrCodeClientException, "Virtual interface dxvif-12345678 is not in a confirmable state.", nil)
+				// The virtual interface becomes confirmable after it has been read as pending.
+				state = directconnect.VirtualInterfaceStateConfirming
+				return
+			}
+			data.VirtualInterfaceState = aws.String(directconnect.VirtualInterfaceStatePending)
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStatePending),
+			}}
+		}
+	})
+
+	err := dxVirtualInterfaceConfirmWhenConfirmable(conn, "dxvif-12345678", 1*time.Minute, func() error {
+		_, err := conn.ConfirmPrivateVirtualInterface(&directconnect.ConfirmPrivateVirtualInterfaceInput{
+			VirtualInterfaceId: aws.String("dxvif-12345678"),
+		})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := []string{"ConfirmPrivateVirtualInterface", "DescribeVirtualInterfaces", "ConfirmPrivateVirtualInterface"}; !reflect.DeepEqual(operations, expected) {
+		t.Errorf("expected operations %v, got %v", expected, operations)
+	}
+}
+
+func TestDxVirtualInterfaceConfirmWhenConfirmable_notConfirmable(t *testing.T) {
+	var operations []string
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		operations = append(operations, r.Operation.Name)
+
+		switch data := r.Data.(type) {
+		case *directconnect.ConfirmPrivateVirtualInterfaceOutput:
+			r.Error = awserr.New(directconnect.ErrCodeClientException, "Virtual interface dxvif-12345678 is not in a confirmable state.", nil)
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateRejected),
+			}}
+		}
+	})
+
+	err := dxVirtualInterfaceConfirmWhenConfirmable(conn, "dxvif-12345678", 1*time.Minute, func() error {
+		_, err := conn.ConfirmPrivateVirtualInterface(&directconnect.ConfirmPrivateVirtualInterfaceInput{
+			VirtualInterfaceId: aws.String("dxvif-12345678"),
+		})
+		return err
+	})
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	// A rejected virtual interface will never become confirmable, so the error is not retried.
+	if expected := []string{"ConfirmPrivateVirtualInterface", "DescribeVirtualInterfaces"}; !reflect.DeepEqual(operations, expected) {
+		t.Errorf("expected operations %v, got %v", expected, operations)
+	}
+}
+
+func TestDxVirtualInterfaceConfirmWhenConfirmable_deleted(t *testing.T) {
+	var operations []string
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		operations = append(operations, r.Operation.Name)
+
+		switch data := r.Data.(type) {
+		case *directconnect.ConfirmPrivateVirtualInterfaceOutput:
+			r.Error = awserr.New(directconnect.ErrCodeClientException, "Virtual interface dxvif-12345678 is not in a confirmable state.", nil)
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateDeleted),
+			}}
+		}
+	})
+
+	err := dxVirtualInterfaceConfirmWhenConfirmable(conn, "dxvif-12345678", 1*time.Minute, func() error {
+		_, err := conn.ConfirmPrivateVirtualInterface(&directconnect.ConfirmPrivateVirtualInterfaceInput{
+			VirtualInterfaceId: aws.String("dxvif-12345678"),
+		})
+		return err
+	})
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	// Unlike one that is not yet visible, a deleted virtual interface will never become confirmable, so the error is not retried.
+	if expected := []string{"ConfirmPrivateVirtualInterface", "DescribeVirtualInterfaces"}; !reflect.DeepEqual(operations, expected) {
+		t.Errorf("expected operations %v, got %v", expected, operations)
+	}
+}
+
+func TestDxVirtualInterfaceConfirmWhenConfirmable_confirming(t *testing.T) {
+	var operations []string
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		operations = append(operations, r.Operation.Name)
+
+		switch data := r.Data.(type) {
+		case *directconnect.ConfirmPrivateVirtualInterfaceOutput:
+			r.Error = awserr.New(directconnect.ErrCodeClientException, "Direct Connect gateway dxgw-12345678 does not exist.", nil)
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateConfirming),
+			}}
+		}
+	})
+
+	err := dxVirtualInterfaceConfirmWhenConfirmable(conn, "dxvif-12345678", 1*time.Minute, func() error {
+		_, err := conn.ConfirmPrivateVirtualInterface(&directconnect.ConfirmPrivateVirtualInterfaceInput{
+			DirectConnectGatewayId: aws.String("dxgw-12345678"),
+			VirtualInterfaceId:     aws.String("dxvif-12345678"),
+		})
+		return err
+	})
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	// The virtual interface is confirmable, so the error is permanent and is not retried.
+	if expected := []string{"ConfirmPrivateVirtualInterface", "DescribeVirtualInterfaces"}; !reflect.DeepEqual(operations, expected) {
+		t.Errorf("expected operations %v, got %v", expected, operations)
+	}
+}
+
+func TestDxVirtualInterfaceDelete_skipDeleteWait(t *testing.T) {
+	var operations []string
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		operations = append(operations, r.Operation.Name)
+
+		switch data := r.Data.(type) {
+		case *directconnect.DeleteVirtualInterfaceOutput:
+			data.VirtualInterfaceState = aws.String(directconnect.VirtualInterfaceStateDeleting)
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+			}}
+		}
+	})
+
+	d := resourceAwsDxPrivateVirtualInterface().Data(nil)
+	d.SetId("dxvif-12345678")
+	d.Set("skip_delete_wait", true)
+
+	if err := dxVirtualInterfaceDelete(d, &AWSClient{dxconn: conn}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := []string{"DescribeVirtualInterfaces", "DeleteVirtualInterface"}; !reflect.DeepEqual(operations, expected) {
+		t.Errorf("expected operations %v, got %v", expected, operations)
+	}
+}
+
+func TestDxVirtualInterfaceDelete_drainBeforeDelete(t *testing.T) {
+	var operations []string
+	bgpStatus := directconnect.BgpStatusUp
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		operations = append(operations, r.Operation.Name)
+
+		switch data := r.Data.(type) {
+		case *directconnect.DeleteVirtualInterfaceOutput:
+			data.VirtualInterfaceState = aws.String(directconnect.VirtualInterfaceStateDeleting)
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				BgpPeers: []*directconnect.BGPPeer{{
+					BgpStatus: aws.String(bgpStatus),
+				}},
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+			}}
+		case *directconnect.StartBgpFailoverTestOutput:
+			bgpStatus = directconnect.BgpStatusDown
+		}
+	})
+
+	d := resourceAwsDxPrivateVirtualInterface().Data(nil)
+	d.SetId("dxvif-12345678")
+	d.Set("drain_before_delete", true)
+	d.Set("prevent_active_delete", true)
+	d.Set("skip_delete_wait", true)
+
+	if err := dxVirtualInterfaceDelete(d, &AWSClient{dxconn: conn}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := []string{"DescribeVirtualInterfaces", "StartBgpFailoverTest", "DescribeVirtualInterfaces", "DeleteVirtualInterface"}; !reflect.DeepEqual(operations, expected) {
+		t.Errorf("expected operations %v, got %v", expected, operations)
+	}
+}
+
+func TestDxVirtualInterfaceDelete_warnIfLastOnConnection(t *testing.T) {
+	var operations []string
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		operations = append(operations, r.Operation.Name)
+
+		switch data := r.Data.(type) {
+		case *directconnect.DeleteVirtualInterfaceOutput:
+			data.VirtualInterfaceState = aws.String(directconnect.VirtualInterfaceStateDeleting)
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			if connectionId := aws.StringValue(r.Params.(*directconnect.DescribeVirtualInterfacesInput).ConnectionId); connectionId != "" && connectionId != "dxcon-12345678" {
+				t.Errorf("unexpected connection ID %q", connectionId)
+			}
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				ConnectionId:          aws.String("dxcon-12345678"),
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+			}}
+		}
+	})
+
+	d := resourceAwsDxPrivateVirtualInterface().Data(nil)
+	d.SetId("dxvif-12345678")
+	d.Set("skip_delete_wait", true)
+	d.Set("warn_if_last_on_connection", true)
+
+	if err := dxVirtualInterfaceDelete(d, &AWSClient{dxconn: conn}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := []string{"DescribeVirtualInterfaces", "DescribeVirtualInterfaces", "DeleteVirtualInterface"}; !reflect.DeepEqual(operations, expected) {
+		t.Errorf("expected operations %v, got %v", expected, operations)
+	}
+}
+
+func TestDxVirtualInterfaceWaitUntilMtuConsistent(t *testing.T) {
+	var describeCalls int
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			describeCalls++
+			mtu := int64(9001)
+			if describeCalls == 1 {
+				// Eventually consistent read of the old MTU.
+				mtu = 1500
+			}
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				Mtu:                   aws.Int64(mtu),
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+			}}
+		}
+	})
+
+	if err := dxVirtualInterfaceWaitUntilMtuConsistent(conn, "dxvif-12345678", 9001, 1*time.Minute); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if describeCalls != 2 {
+		t.Errorf("expected DescribeVirtualInterfaces to be called 2 times, got %d", describeCalls)
+	}
+}
+
+func TestDxVirtualInterfaceWaitUntilMtuConsistent_timeout(t *testing.T) {
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				Mtu:                   aws.Int64(1500),
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+			}}
+		}
+	})
+
+	// The update has been made so a stale read once the budget expires is not an error.
+	if err := dxVirtualInterfaceWaitUntilMtuConsistent(conn, "dxvif-12345678", 9001, 1*time.Second); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestDxVirtualInterfaceWaitUntilAvailable_transientlyNotFound(t *testing.T) {
+	testDxVirtualInterfaceShortenAvailableWait(t)
+
+	var describeCalls int
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			describeCalls++
+			// Missing from the first few reads after creation.
+			if describeCalls <= 3 {
+				return
+			}
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+			}}
+		}
+	})
+
+	pending := []string{directconnect.VirtualInterfaceStatePending}
+	target := []string{directconnect.VirtualInterfaceStateAvailable}
+	if err := dxVirtualInterfaceWaitUntilAvailable(conn, "dxvif-12345678", 1*time.Minute, pending, target); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if describeCalls != 4 {
+		t.Errorf("expected DescribeVirtualInterfaces to be called 4 times, got %d", describeCalls)
+	}
+}
+
+func TestDxVirtualInterfaceWaitUntilAvailable_notFound(t *testing.T) {
+	testDxVirtualInterfaceShortenAvailableWait(t)
+
+	var describeCalls int
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch r.Data.(type) {
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			describeCalls++
+		}
+	})
+
+	pending := []string{directconnect.VirtualInterfaceStatePending}
+	target := []string{directconnect.VirtualInterfaceStateAvailable}
+	if err := dxVirtualInterfaceWaitUntilAvailable(conn, "dxvif-12345678", 1*time.Minute, pending, target); err == nil {
+		t.Fatal("expected error for a virtual interface that is never found")
+	}
+
+	if expected := dxVirtualInterfaceNotFoundChecks + 1; describeCalls != expected {
+		t.Errorf("expected DescribeVirtualInterfaces to be called %d times, got %d", expected, describeCalls)
+	}
+}
+
+func TestDxPeerAddressesConsistent(t *testing.T) {
+	testCases := []struct {
+		AmazonAddress   string
+		CustomerAddress string
+		Expected        bool
+	}{
+		{"169.254.255.1/30", "169.254.255.2/30", true},
+		{"169.254.255.1/30", "169.254.255.5/30", false},
+		{"169.254.255.1/30", "169.254.255.1/30", false},
+		{"169.254.255.1/30", "169.254.255.2/29", false},
+		{"2001:db8::1/125", "2001:db8::2/125", true},
+		{"2001:db8::1/125", "2001:db8::9/125", false},
+		{"169.254.255.1/30", "", false},
+		{"", "", false},
+		{"169.254.255.1", "169.254.255.2", false},
+	}
+
+	for _, testCase := range testCases {
+		if got := dxPeerAddressesConsistent(testCase.AmazonAddress, testCase.CustomerAddress); got != testCase.Expected {
+			t.Errorf("%q, %q: got %t, expected %t", testCase.AmazonAddress, testCase.CustomerAddress, got, testCase.Expected)
+		}
+	}
+}
+
+func TestDxGenerateBgpAuthKey(t *testing.T) {
+	testCases := []struct {
+		length  int
+		charset string
+	}{
+		{length: dxBgpAuthKeyMinLength, charset: dxBgpAuthKeyDefaultCharset},
+		{length: dxBgpAuthKeyMaxLength, charset: dxBgpAuthKeyDefaultCharset},
+		{length: 32, charset: "0123456789abcdef"},
+		{length: 16, charset: "x"},
+	}
+
+	for _, testCase := range testCases {
+		key, err := dxGenerateBgpAuthKey(testCase.length, testCase.charset)
+
+		if err != nil {
+			t.Errorf("length = %d, charset = %q: unexpected error: %s", testCase.length, testCase.charset, err)
+			continue
+		}
+		if len(key) != testCase.length {
+			t.Errorf("length = %d, charset = %q: got key of length %d", testCase.length, testCase.charset, len(key))
+		}
+		if trimmed := strings.Trim(key, testCase.charset); trimmed != "" {
+			t.Errorf("length = %d, charset = %q: key contains characters outside the charset: %q", testCase.length, testCase.charset, trimmed)
+		}
+	}
+
+	if _, err := dxGenerateBgpAuthKey(16, ""); err == nil {
+		t.Error("empty charset: expected error, got none")
+	}
+}
+
+func TestFlattenDxVirtualInterfaceBgpPeerIds(t *testing.T) {
+	vif := &directconnect.VirtualInterface{
+		BgpPeers: []*directconnect.BGPPeer{
+			{BgpPeerId: aws.String("dxpeer-00000001")},
+			nil,
+			{},
+			{BgpPeerId: aws.String("dxpeer-00000002")},
+		},
+	}
+
+	if got, expected := flattenDxVirtualInterfaceBgpPeerIds(vif), []string{"dxpeer-00000001", "dxpeer-00000002"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	if got := flattenDxVirtualInterfaceBgpPeerIds(&directconnect.VirtualInterface{}); len(got) != 0 {
+		t.Errorf("expected no BGP peer IDs, got %v", got)
+	}
+}
+
+func TestDxVirtualInterfaceMtuValidation(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Resource *schema.Resource
+		Valid    []int
+	}{
+		{"aws_dx_private_virtual_interface", resourceAwsDxPrivateVirtualInterface(), []int{1500, 9001}},
+		{"aws_dx_hosted_private_virtual_interface", resourceAwsDxHostedPrivateVirtualInterface(), []int{1500, 9001}},
+		{"aws_dx_transit_virtual_interface", resourceAwsDxTransitVirtualInterface(), []int{1500, 8500}},
+		{"aws_dx_hosted_transit_virtual_interface", resourceAwsDxHostedTransitVirtualInterface(), []int{1500, 8500}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			validateFunc := testCase.Resource.Schema["mtu"].ValidateFunc
+
+			for _, mtu := range testCase.Valid {
+				if _, errs := validateFunc(mtu, "mtu"); len(errs) > 0 {
+					t.Errorf("expected MTU %d to be valid, got %v", mtu, errs)
+				}
+			}
+
+			if _, errs := validateFunc(1400, "mtu"); len(errs) == 0 {
+				t.Error("expected MTU 1400 to be invalid")
+			}
+		})
+	}
+}
+
+func TestDxOrderedRouteFilterPrefixes(t *testing.T) {
+	routeFilterPrefixes := schema.NewSet(schema.HashString, []interface{}{"175.45.176.0/22", "210.52.109.0/24"})
+
+	// The configured order is kept.
+	if got, expected := dxOrderedRouteFilterPrefixes([]interface{}{"210.52.109.0/24", "175.45.176.0/22"}, routeFilterPrefixes), []string{"210.52.109.0/24", "175.45.176.0/22"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+
+	// Changed outside of Terraform.
+	if got, expected := dxOrderedRouteFilterPrefixes([]interface{}{"210.52.109.0/24"}, routeFilterPrefixes), []string{"175.45.176.0/22", "210.52.109.0/24"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+}
+
+func TestDxRouterConfigurationBgpTimers(t *testing.T) {
+	testCases := []struct {
+		Name              string
+		Config            string
+		ExpectedKeepalive int
+		ExpectedHoldTime  int
+	}{
+		{
+			Name:   "no timers",
+			Config: "router bgp 65000\n neighbor 169.254.255.1 remote-as 64512\n",
+		},
+		{
+			Name:              "Cisco",
+			Config:            "router bgp 65000\n neighbor 169.254.255.1 remote-as 64512\n neighbor 169.254.255.1 timers 10 30\n",
+			ExpectedKeepalive: 10,
+			ExpectedHoldTime:  30,
+		},
+		{
+			Name:              "Junos",
+			Config:            "protocols {\n  bgp {\n    group ebgp {\n      hold-time 90;\n    }\n  }\n}\n",
+			ExpectedKeepalive: 30,
+			ExpectedHoldTime:  90,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			keepalive, holdTime := dxRouterConfigurationBgpTimers(testCase.Config)
+
+			if keepalive != testCase.ExpectedKeepalive || holdTime != testCase.ExpectedHoldTime {
+				t.Errorf("got keepalive %d, hold time %d, expected %d, %d", keepalive, holdTime, testCase.ExpectedKeepalive, testCase.ExpectedHoldTime)
+			}
+		})
+	}
+}
+
+func TestDxVirtualInterfaceRole(t *testing.T) {
+	testCases := []struct {
+		OwnerAccount string
+		Accepter     bool
+		Expected     string
+	}{
+		{OwnerAccount: "123456789012", Accepter: false, Expected: dxVirtualInterfaceRoleCreator},
+		// Hosted virtual interface provisioned for another account.
+		{OwnerAccount: "210987654321", Accepter: false, Expected: dxVirtualInterfaceRoleCreator},
+		{OwnerAccount: "123456789012", Accepter: true, Expected: dxVirtualInterfaceRoleAccepter},
+		{OwnerAccount: "210987654321", Accepter: true, Expected: dxVirtualInterfaceRoleCreator},
+	}
+
+	for _, testCase := range testCases {
+		vif := &directconnect.VirtualInterface{OwnerAccount: aws.String(testCase.OwnerAccount)}
+
+		if got := dxVirtualInterfaceRole(vif, "123456789012", testCase.Accepter); got != testCase.Expected {
+			t.Errorf("owner %s, accepter %t: got %s, expected %s", testCase.OwnerAccount, testCase.Accepter, got, testCase.Expected)
+		}
+	}
+}
+
+func TestDxVirtualInterfaceStateIsTerminal(t *testing.T) {
+	for _, state := range directconnect.VirtualInterfaceState_Values() {
+		expected := state == directconnect.VirtualInterfaceStateDeleted
+		if got := dxVirtualInterfaceStateIsTerminal(state); got != expected {
+			t.Errorf("dxVirtualInterfaceStateIsTerminal(%q) = %t, expected %t", state, got, expected)
+		}
+	}
+}
+
+func TestDxVirtualInterfaceValidatePeerAddresses(t *testing.T) {
+	testCases := []struct {
+		addressFamily   string
+		amazonAddress   string
+		customerAddress string
+		expectError     bool
+	}{
+		{addressFamily: "ipv4", amazonAddress: "", customerAddress: "", expectError: false},
+		{addressFamily: "ipv4", amazonAddress: "175.45.176.1/30", customerAddress: "175.45.176.2/30", expectError: false},
+		{addressFamily: "ipv4", amazonAddress: "175.45.176.1/30", customerAddress: "", expectError: true},
+		{addressFamily: "ipv4", amazonAddress: "", customerAddress: "175.45.176.2/30", expectError: true},
+		{addressFamily: "ipv6", amazonAddress: "", customerAddress: "", expectError: false},
+		{addressFamily: "ipv6", amazonAddress: "2001:db8::1/125", customerAddress: "2001:db8::2/125", expectError: false},
+		{addressFamily: "ipv6", amazonAddress: "2001:db8::1/126", customerAddress: "2001:db8::2/126", expectError: false},
+		{addressFamily: "ipv6", amazonAddress: "2001:db8::1/64", customerAddress: "2001:db8::2/64", expectError: true},
+		{addressFamily: "ipv6", amazonAddress: "2001:db8::1/125", customerAddress: "2001:db8::2/127", expectError: true},
+		{addressFamily: "ipv6", amazonAddress: "175.45.176.1/30", customerAddress: "175.45.176.2/30", expectError: true},
+		{addressFamily: "ipv6", amazonAddress: "2001:db8::1", customerAddress: "2001:db8::2", expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		err := dxVirtualInterfaceValidatePeerAddresses(testCase.addressFamily, testCase.amazonAddress, testCase.customerAddress)
+
+		if testCase.expectError && err == nil {
+			t.Errorf("address_family = %q, amazon_address = %q, customer_address = %q: expected error, got none", testCase.addressFamily, testCase.amazonAddress, testCase.customerAddress)
+		}
+		if !testCase.expectError && err != nil {
+			t.Errorf("address_family = %q, amazon_address = %q, customer_address = %q: unexpected error: %s", testCase.addressFamily, testCase.amazonAddress, testCase.customerAddress, err)
+		}
+	}
+}
+
+func TestDxVirtualInterfaceValidateIpv6AddressAssignment(t *testing.T) {
+	testCases := []struct {
+		Assignment      string
+		AddressFamily   string
+		AmazonAddress   string
+		CustomerAddress string
+		ExpectError     bool
+	}{
+		{"", "ipv4", "", "", false},
+		{"", "ipv6", "2001:db8::1/125", "2001:db8::2/125", false},
+		{"auto", "ipv6", "", "", false},
+		{"auto", "ipv6", "2001:db8::1/125", "2001:db8::2/125", true},
+		{"auto", "ipv4", "", "", true},
+		{"manual", "ipv6", "2001:db8::1/125", "2001:db8::2/125", false},
+		{"manual", "ipv6", "", "", true},
+		{"manual", "ipv6", "2001:db8::1/125", "", true},
+	}
+
+	for _, testCase := range testCases {
+		err := dxVirtualInterfaceValidateIpv6AddressAssignment(testCase.Assignment, testCase.AddressFamily, testCase.AmazonAddress, testCase.CustomerAddress)
+
+		if testCase.ExpectError && err == nil {
+			t.Errorf("%q, %q, %q, %q: expected error, got none", testCase.Assignment, testCase.AddressFamily, testCase.AmazonAddress, testCase.CustomerAddress)
+		}
+		if !testCase.ExpectError && err != nil {
+			t.Errorf("%q, %q, %q, %q: unexpected error: %s", testCase.Assignment, testCase.AddressFamily, testCase.AmazonAddress, testCase.CustomerAddress, err)
+		}
+	}
+}
+
+func TestValidateDxTransitVirtualInterfaceGatewayId(t *testing.T) {
+	validIds := []string{
+		"5f294f92-bafb-4011-916d-9b0bec223120",
+	}
+	for _, v := range validIds {
+		if _, errors := validateDxTransitVirtualInterfaceGatewayId(v, "dx_gateway_id"); len(errors) != 0 {
+			t.Errorf("%q should be a valid transit virtual interface gateway ID: %q", v, errors)
+		}
+	}
+
+	invalidIds := []string{
+		// Missing gateway.
+		"",
+		// Wrong gateway type.
+		"vgw-0123456789abcdef0",
+		"tgw-0123456789abcdef0",
+	}
+	for _, v := range invalidIds {
+		if _, errors := validateDxTransitVirtualInterfaceGatewayId(v, "dx_gateway_id"); len(errors) == 0 {
+			t.Errorf("%q should be an invalid transit virtual interface gateway ID", v)
+		}
+	}
+}
+
+func TestDxSortCidrs(t *testing.T) {
+	cidrs := []string{
+		"2001:db8::/32",
+		"210.52.109.0/24",
+		"invalid",
+		"9.0.0.0/8",
+		"175.45.176.0/24",
+		"175.45.176.0/22",
+		"2001:db8::/48",
+	}
+	expected := []string{
+		"9.0.0.0/8",
+		"175.45.176.0/22",
+		"175.45.176.0/24",
+		"210.52.109.0/24",
+		"2001:db8::/32",
+		"2001:db8::/48",
+		"invalid",
+	}
+
+	if got := dxSortCidrs(cidrs); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestDxMissingTagKeys(t *testing.T) {
+	tags := keyvaluetags.New(map[string]string{
+		"CostCenter": "1234",
+		"Name":       "",
+	})
+
+	testCases := []struct {
+		Keys     []string
+		Expected []string
+	}{
+		{Keys: nil, Expected: nil},
+		{Keys: []string{"CostCenter"}, Expected: nil},
+		// Tags with empty values are present.
+		{Keys: []string{"Name"}, Expected: nil},
+		{Keys: []string{"Team", "CostCenter", "Owner"}, Expected: []string{"Owner", "Team"}},
+	}
+
+	for _, testCase := range testCases {
+		if got := dxMissingTagKeys(tags, testCase.Keys); !reflect.DeepEqual(got, testCase.Expected) {
+			t.Errorf("keys %v: got %v, expected %v", testCase.Keys, got, testCase.Expected)
+		}
+	}
+}
+
+func TestDxDuplicateCidrs(t *testing.T) {
+	cidrs := []string{
+		"175.45.176.0/22",
+		"175.45.176.1/22",
+		"175.45.176.0/24",
+		"2001:DB8::/32",
+		"2001:db8::/32",
+		"invalid",
+	}
+	expected := map[string][]string{
+		"175.45.176.0/22": {"175.45.176.0/22", "175.45.176.1/22"},
+		"2001:db8::/32":   {"2001:DB8::/32", "2001:db8::/32"},
+	}
+
+	if got := dxDuplicateCidrs(cidrs); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestDxVirtualInterfaceCheckVlanAvailable(t *testing.T) {
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{
+				{
+					VirtualInterfaceId:    aws.String("dxvif-11111111"),
+					VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+					Vlan:                  aws.Int64(100),
+				},
+				{
+					VirtualInterfaceId:    aws.String("dxvif-22222222"),
+					VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateDeleted),
+					Vlan:                  aws.Int64(200),
+				},
+			}
+		}
+	})
+
+	if err := dxVirtualInterfaceCheckVlanAvailable(conn, "dxcon-12345678", 100, ""); err == nil {
+		t.Error("VLAN 100: expected error, got none")
+	}
+	if err := dxVirtualInterfaceCheckVlanAvailable(conn, "dxcon-12345678", 200, ""); err != nil {
+		t.Errorf("VLAN 200: unexpected error: %s", err)
+	}
+	if err := dxVirtualInterfaceCheckVlanAvailable(conn, "dxcon-12345678", 300, ""); err != nil {
+		t.Errorf("VLAN 300: unexpected error: %s", err)
+	}
+}
+
+func TestDxJitter(t *testing.T) {
+	d := 10 * time.Second
+
+	for i := 0; i < 100; i++ {
+		if got := dxJitter(d); got < d || got > d+d/2 {
+			t.Fatalf("dxJitter(%s) = %s, expected value in [%s, %s]", d, got, d, d+d/2)
+		}
+	}
+}
+
+func TestDxConnectionJumboFrameCapable_cached(t *testing.T) {
+	var describeConnectionsCalls int
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.Connections:
+			describeConnectionsCalls++
+			data.Connections = []*directconnect.Connection{{
+				ConnectionId:      aws.String("dxcon-jumbo001"),
+				JumboFrameCapable: aws.Bool(false),
+			}}
+		}
+	})
+
+	for i := 0; i < 3; i++ {
+		jumboFrameCapable, err := dxConnectionJumboFrameCapable(conn, "dxcon-jumbo001")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if jumboFrameCapable == nil || aws.BoolValue(jumboFrameCapable) {
+			t.Fatalf("expected connection not to be jumbo frame capable, got %v", jumboFrameCapable)
+		}
+	}
+
+	if describeConnectionsCalls != 1 {
+		t.Errorf("expected DescribeConnections to be called 1 time, got %d", describeConnectionsCalls)
+	}
+}
+
+func TestDxConnectionJumboFrameCapable_lagNotFoundNotCached(t *testing.T) {
+	var describeLagsCalls int
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeLagsOutput:
+			describeLagsCalls++
+			// Transiently missing on the first read.
+			if describeLagsCalls == 1 {
+				return
+			}
+			data.Lags = []*directconnect.Lag{{
+				JumboFrameCapable: aws.Bool(true),
+				LagId:             aws.String("dxlag-jumbo001"),
+			}}
+		}
+	})
+
+	jumboFrameCapable, err := dxConnectionJumboFrameCapable(conn, "dxlag-jumbo001")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if jumboFrameCapable != nil {
+		t.Fatalf("expected LAG not to be found, got %v", jumboFrameCapable)
+	}
+
+	for i := 0; i < 2; i++ {
+		jumboFrameCapable, err = dxConnectionJumboFrameCapable(conn, "dxlag-jumbo001")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !aws.BoolValue(jumboFrameCapable) {
+			t.Fatalf("expected LAG to be jumbo frame capable, got %v", jumboFrameCapable)
+		}
+	}
+
+	if describeLagsCalls != 2 {
+		t.Errorf("expected DescribeLags to be called 2 times, got %d", describeLagsCalls)
+	}
+}
+
+func TestDxConnectionLookup_retriedAndCached(t *testing.T) {
+	var describeConnectionsCalls int
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.Connections:
+			describeConnectionsCalls++
+			if describeConnectionsCalls == 1 {
+				r.Error = awserr.New(directconnect.ErrCodeServerException, "internal error", nil)
+				return
+			}
+			data.Connections = []*directconnect.Connection{{
+				ConnectionId:         aws.String("dxcon-lookup01"),
+				PortEncryptionStatus: aws.String("Encryption Up"),
+			}}
+		}
+	})
+
+	for i := 0; i < 3; i++ {
+		connection, err := dxConnectionLookup(conn, "dxcon-lookup01")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if connection == nil || aws.StringValue(connection.PortEncryptionStatus) != "Encryption Up" {
+			t.Fatalf("unexpected connection: %v", connection)
+		}
+	}
+
+	if describeConnectionsCalls != 2 {
+		t.Errorf("expected DescribeConnections to be called 2 times, got %d", describeConnectionsCalls)
+	}
+}
+
+func TestDxConnectionLookup_notFoundNotCached(t *testing.T) {
+	var describeConnectionsCalls int
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.Connections:
+			describeConnectionsCalls++
+			// The connection is only visible from the second call.
+			if describeConnectionsCalls > 1 {
+				data.Connections = []*directconnect.Connection{{
+					ConnectionId: aws.String("dxcon-lookup02"),
+				}}
+			}
+		}
+	})
+
+	connection, err := dxConnectionLookup(conn, "dxcon-lookup02")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if connection != nil {
+		t.Fatalf("expected no connection, got %v", connection)
+	}
+
+	connection, err = dxConnectionLookup(conn, "dxcon-lookup02")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if connection == nil {
+		t.Fatal("expected connection, got none")
+	}
+}
+
+func TestDxConnectionLookup_notSerialized(t *testing.T) {
+	release := make(chan struct{})
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.Connections:
+			connectionId := aws.StringValue(r.Params.(*directconnect.DescribeConnectionsInput).ConnectionId)
+			if connectionId == "dxcon-slow0001" {
+				<-release
+			}
+			data.Connections = []*directconnect.Connection{{
+				ConnectionId: aws.String(connectionId),
+			}}
+		}
+	})
+
+	slowDone := make(chan struct{})
+	go func() {
+		defer close(slowDone)
+		_, _ = dxConnectionLookup(conn, "dxcon-slow0001")
+	}()
+
+	fastDone := make(chan struct{})
+	go func() {
+		defer close(fastDone)
+		_, _ = dxConnectionLookup(conn, "dxcon-fast0001")
+	}()
+
+	// The lookup of one connection must not wait for a slow lookup of another.
+	select {
+	case <-fastDone:
+	case <-time.After(10 * time.Second):
+		t.Error("lookup of dxcon-fast0001 blocked by lookup of dxcon-slow0001")
+	}
+
+	close(release)
+	<-slowDone
+	<-fastDone
+}
+
+func TestDxVirtualInterfaceArn(t *testing.T) {
+	testCases := []struct {
+		Partition string
+		Region    string
+		Expected  string
+	}{
+		{
+			Partition: "aws",
+			Region:    "us-west-2",
+			Expected:  "arn:aws:directconnect:us-west-2:123456789012:dxvif/dxvif-12345678",
+		},
+		{
+			Partition: "aws-cn",
+			Region:    "cn-north-1",
+			Expected:  "arn:aws-cn:directconnect:cn-north-1:123456789012:dxvif/dxvif-12345678",
+		},
+		{
+			Partition: "aws-us-gov",
+			Region:    "us-gov-west-1",
+			Expected:  "arn:aws-us-gov:directconnect:us-gov-west-1:123456789012:dxvif/dxvif-12345678",
+		},
+	}
+
+	for _, testCase := range testCases {
+		client := &AWSClient{partition: testCase.Partition, region: testCase.Region}
+
+		if got := dxVirtualInterfaceArn(client, "123456789012", "dxvif-12345678"); got != testCase.Expected {
+			t.Errorf("partition %s: got %s, expected %s", testCase.Partition, got, testCase.Expected)
+		}
+	}
+}
+
+func TestDxConnectionVlanInUseBy(t *testing.T) {
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{
+				{
+					VirtualInterfaceId:    aws.String("dxvif-00000001"),
+					VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+					Vlan:                  aws.Int64(100),
+				},
+				{
+					VirtualInterfaceId:    aws.String("dxvif-00000002"),
+					VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateDeleted),
+					Vlan:                  aws.Int64(200),
+				},
+			}
+		}
+	})
+
+	testCases := []struct {
+		Vlan         int
+		ExcludeVifId string
+		Expected     string
+	}{
+		{Vlan: 100, Expected: "dxvif-00000001"},
+		{Vlan: 100, ExcludeVifId: "dxvif-00000001", Expected: ""},
+		// Deleted virtual interfaces no longer use their VLAN.
+		{Vlan: 200, Expected: ""},
+		{Vlan: 300, Expected: ""},
+	}
+
+	for _, testCase := range testCases {
+		got, err := dxConnectionVlanInUseBy(conn, "dxcon-12345678", testCase.Vlan, testCase.ExcludeVifId)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got != testCase.Expected {
+			t.Errorf("vlan %d excluding %q: got %q, expected %q", testCase.Vlan, testCase.ExcludeVifId, got, testCase.Expected)
+		}
+	}
+}
+
+func TestDxVirtualInterfaceStateRefresh_transientServerError(t *testing.T) {
+	var describeCalls int
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			describeCalls++
+			if describeCalls == 2 {
+				r.Error = awserr.New(directconnect.ErrCodeServerException, "internal error", nil)
+				return
+			}
+
+			state := directconnect.VirtualInterfaceStatePending
+			if describeCalls > 2 {
+				state = directconnect.VirtualInterfaceStateAvailable
+			}
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(state),
+			}}
+		}
+	})
+
+	refresh := dxVirtualInterfaceStateRefresh(conn, "dxvif-12345678")
+
+	for i, expected := range []string{
+		directconnect.VirtualInterfaceStatePending,
+		directconnect.VirtualInterfaceStatePending, // Last known state on the transient error.
+		directconnect.VirtualInterfaceStateAvailable,
+	} {
+		_, state, err := refresh()
+		if err != nil {
+			t.Fatalf("refresh %d: unexpected error: %s", i, err)
+		}
+		if state != expected {
+			t.Errorf("refresh %d: expected state %s, got %s", i, expected, state)
+		}
+	}
+}
+
+func TestDxVirtualInterfaceStateRefresh_serverErrorBeforeFirstState(t *testing.T) {
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		r.Error = awserr.New(directconnect.ErrCodeServerException, "internal error", nil)
+	})
+
+	if _, _, err := dxVirtualInterfaceStateRefresh(conn, "dxvif-12345678")(); err == nil {
+		t.Error("expected error, got none")
+	}
+}
+
+// testDxConnWithStub returns a Direct Connect client whose requests are answered by the specified handler.
+// testDxVirtualInterfaceShortenAvailableWait removes the delay and minimum poll interval of dxVirtualInterfaceWaitUntilAvailable for the duration of a test.
+func testDxVirtualInterfaceShortenAvailableWait(t *testing.T) {
+	delay, minTimeout := dxVirtualInterfaceAvailableDelay, dxVirtualInterfaceAvailableMinTimeout
+	dxVirtualInterfaceAvailableDelay, dxVirtualInterfaceAvailableMinTimeout = 0, 0
+
+	t.Cleanup(func() {
+		dxVirtualInterfaceAvailableDelay, dxVirtualInterfaceAvailableMinTimeout = delay, minTimeout
+	})
+}
+
+func testDxConnWithStub(t *testing.T, send func(*request.Request)) *directconnect.DirectConnect {
+	sess, err := session.NewSession(nil)
+	if err != nil {
+		t.Fatalf("error creating session: %s", err)
+	}
+
+	conn := directconnect.New(sess)
+	conn.Handlers.Clear()
+	conn.Handlers.Send.PushBack(send)
+
+	return conn
+}
+
 func testAccCheckDxVirtualInterfaceExists(name string, vif *directconnect.VirtualInterface) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		conn := testAccProvider.Meta().(*AWSClient).dxconn
@@ -40,6 +1275,17 @@ func testAccCheckDxVirtualInterfaceExists(name string, vif *directconnect.Virtua
 	}
 }
 
+// testAccCheckDxVirtualInterfaceTag checks that the virtual interface, as last read by testAccCheckDxVirtualInterfaceExists, has the tag in AWS.
+func testAccCheckDxVirtualInterfaceTag(vif *directconnect.VirtualInterface, key, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if v := keyvaluetags.DirectconnectKeyValueTags(vif.Tags).KeyValue(key); aws.StringValue(v) != value {
+			return fmt.Errorf("Direct Connect virtual interface (%s) tag %q is %q, expected %q", aws.StringValue(vif.VirtualInterfaceId), key, aws.StringValue(v), value)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckDxVirtualInterfaceDestroy(s *terraform.State, t string) error {
 	conn := testAccProvider.Meta().(*AWSClient).dxconn
 
@@ -62,7 +1308,7 @@ func testAccCheckDxVirtualInterfaceDestroy(s *terraform.State, t string) error {
 		}
 
 		for _, v := range resp.VirtualInterfaces {
-			if aws.StringValue(v.VirtualInterfaceId) == rs.Primary.ID && aws.StringValue(v.VirtualInterfaceState) != directconnect.VirtualInterfaceStateDeleted {
+			if aws.StringValue(v.VirtualInterfaceId) == rs.Primary.ID && !dxVirtualInterfaceStateIsTerminal(aws.StringValue(v.VirtualInterfaceState)) {
 				return fmt.Errorf("[DESTROY ERROR] Direct Connect virtual interface (%s) not deleted", rs.Primary.ID)
 			}
 		}
@@ -238,7 +238,14 @@ func Provider() *schema.Provider {
 			"aws_directory_service_directory":                dataSourceAwsDirectoryServiceDirectory(),
 			"aws_docdb_engine_version":                       dataSourceAwsDocdbEngineVersion(),
 			"aws_docdb_orderable_db_instance":                dataSourceAwsDocdbOrderableDbInstance(),
+			"aws_dx_available_vlan":                          dataSourceAwsDxAvailableVlan(),
+			"aws_dx_bgp_peers":                               dataSourceAwsDxBgpPeers(),
+			"aws_dx_connection_macsec_status":                dataSourceAwsDxConnectionMacsecStatus(),
+			"aws_dx_connection_utilization":                  dataSourceAwsDxConnectionUtilization(),
 			"aws_dx_gateway":                                 dataSourceAwsDxGateway(),
+			"aws_dx_loa":                                     dataSourceAwsDxLoa(),
+			"aws_dx_virtual_interface":                       dataSourceAwsDxVirtualInterface(),
+			"aws_dx_virtual_interfaces":                      dataSourceAwsDxVirtualInterfaces(),
 			"aws_dynamodb_table":                             dataSourceAwsDynamoDbTable(),
 			"aws_ebs_default_kms_key":                        dataSourceAwsEbsDefaultKmsKey(),
 			"aws_ebs_encryption_by_default":                  dataSourceAwsEbsEncryptionByDefault(),
@@ -660,6 +667,7 @@ func Provider() *schema.Provider {
 			"aws_dx_bgp_peer":                                         resourceAwsDxBgpPeer(),
 			"aws_dx_connection":                                       resourceAwsDxConnection(),
 			"aws_dx_connection_association":                           resourceAwsDxConnectionAssociation(),
+			"aws_dx_connection_virtual_interface_tags":                resourceAwsDxConnectionVirtualInterfaceTags(),
 			"aws_dx_gateway":                                          resourceAwsDxGateway(),
 			"aws_dx_gateway_association":                              resourceAwsDxGatewayAssociation(),
 			"aws_dx_gateway_association_proposal":                     resourceAwsDxGatewayAssociationProposal(),
@@ -669,6 +677,7 @@ func Provider() *schema.Provider {
 			"aws_dx_hosted_public_virtual_interface_accepter":         resourceAwsDxHostedPublicVirtualInterfaceAccepter(),
 			"aws_dx_hosted_transit_virtual_interface":                 resourceAwsDxHostedTransitVirtualInterface(),
 			"aws_dx_hosted_transit_virtual_interface_accepter":        resourceAwsDxHostedTransitVirtualInterfaceAccepter(),
+			"aws_dx_interconnect":                                     resourceAwsDxInterconnect(),
 			"aws_dx_lag":                                              resourceAwsDxLag(),
 			"aws_dx_private_virtual_interface":                        resourceAwsDxPrivateVirtualInterface(),
 			"aws_dx_public_virtual_interface":                         resourceAwsDxPublicVirtualInterface(),
@@ -26,9 +26,10 @@ func resourceAwsDxBgpPeer() *schema.Resource {
 				ValidateFunc: validation.StringInSlice([]string{directconnect.AddressFamilyIpv4, directconnect.AddressFamilyIpv6}, false),
 			},
 			"bgp_asn": {
-				Type:     schema.TypeInt,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDxBgpAsn,
 			},
 			"virtual_interface_id": {
 				Type:     schema.TypeString,
@@ -42,10 +43,11 @@ func resourceAwsDxBgpPeer() *schema.Resource {
 				ForceNew: true,
 			},
 			"bgp_auth_key": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-				ForceNew: true,
+				Type:      schema.TypeString,
+				Optional:  true,
+				Computed:  true,
+				ForceNew:  true,
+				Sensitive: true,
 			},
 			"customer_address": {
 				Type:     schema.TypeString,
@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -8,8 +9,10 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
 )
 
@@ -28,6 +31,12 @@ func resourceAwsDxConnection() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"encryption_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice(dxConnectionEncryptionModes, false),
+			},
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -48,6 +57,23 @@ func resourceAwsDxConnection() *schema.Resource {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+			"lag_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"loa_issue_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"partition": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"port_encryption_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"tags":     tagsSchema(),
 			"tags_all": tagsSchemaComputed(),
 			"has_logical_redundancy": {
@@ -58,10 +84,117 @@ func resourceAwsDxConnection() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"mac_sec_capable": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"request_macsec": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+			"vlan": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
 		},
 
-		CustomizeDiff: SetTagsDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		CustomizeDiff: customdiff.Sequence(
+			SetTagsDiff,
+			dxConnectionBandwidthCustomizeDiff,
+			dxConnectionEncryptionModeCustomizeDiff,
+		),
+	}
+}
+
+// dxConnectionBandwidthCustomizeDiff warns when the bandwidth of an existing connection changes.
+// The Direct Connect API cannot change the bandwidth of a dedicated connection, so the connection is recreated,
+// which requires a new LOA-CFA and cross connect and drops the connection's virtual interfaces.
+func dxConnectionBandwidthCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("bandwidth") {
+		return nil
+	}
+
+	o, n := diff.GetChange("bandwidth")
+	log.Printf("[WARN] changing 'bandwidth' (%s => %s) recreates Direct Connect connection (%s). The new connection requires a new LOA-CFA and cross connect, and the virtual interfaces on the existing connection are deleted with it", o, n, diff.Id())
+
+	return nil
+}
+
+const (
+	dxConnectionEncryptionModeNoEncrypt     = "no_encrypt"
+	dxConnectionEncryptionModeShouldEncrypt = "should_encrypt"
+	dxConnectionEncryptionModeMustEncrypt   = "must_encrypt"
+
+	dxPortEncryptionStatusUp = "Encryption Up"
+)
+
+var dxConnectionEncryptionModes = []string{
+	dxConnectionEncryptionModeNoEncrypt,
+	dxConnectionEncryptionModeShouldEncrypt,
+	dxConnectionEncryptionModeMustEncrypt,
+}
+
+// dxConnectionEncryptionModeCustomizeDiff rejects setting "encryption_mode" on a new connection,
+// as MACsec keys must be associated with the connection first, and rejects switching an existing connection
+// to must_encrypt unless it is MACsec capable and has MACsec keys, as it would otherwise never carry traffic again.
+// Otherwise it warns: the connection drops traffic until MACsec is up, whereas with should_encrypt
+// it falls back to unencrypted traffic.
+func dxConnectionEncryptionModeCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" {
+		if v, ok := diff.GetOk("encryption_mode"); ok && v.(string) != "" && v.(string) != dxConnectionEncryptionModeNoEncrypt {
+			return fmt.Errorf("encryption_mode cannot be set when creating a Direct Connect connection; associate MACsec keys with the connection first")
+		}
+
+		return nil
+	}
+
+	if !diff.HasChange("encryption_mode") {
+		return nil
 	}
+
+	o, n := diff.GetChange("encryption_mode")
+	if n.(string) == dxConnectionEncryptionModeMustEncrypt {
+		conn := meta.(*AWSClient).dxconn
+
+		resp, err := conn.DescribeConnections(&directconnect.DescribeConnectionsInput{
+			ConnectionId: aws.String(diff.Id()),
+		})
+		if err != nil {
+			return fmt.Errorf("error reading Direct Connect connection (%s): %w", diff.Id(), err)
+		}
+		if len(resp.Connections) != 1 {
+			return fmt.Errorf("Direct Connect connection (%s) not found", diff.Id())
+		}
+
+		if err := dxConnectionValidateMustEncrypt(resp.Connections[0]); err != nil {
+			return err
+		}
+
+		log.Printf("[WARN] changing 'encryption_mode' (%s => %s) on Direct Connect connection (%s) drops all traffic on the connection until MACsec is up. Use %s to keep the connection up while MACsec is established", o, n, diff.Id(), dxConnectionEncryptionModeShouldEncrypt)
+	}
+
+	return nil
+}
+
+// dxConnectionValidateMustEncrypt returns an error if the connection cannot carry traffic with must_encrypt,
+// i.e. if it is not MACsec capable or has no MACsec keys with which to establish MACsec.
+func dxConnectionValidateMustEncrypt(connection *directconnect.Connection) error {
+	connectionId := aws.StringValue(connection.ConnectionId)
+
+	if !aws.BoolValue(connection.MacSecCapable) {
+		return fmt.Errorf("'encryption_mode' cannot be %s: Direct Connect connection (%s) is not MACsec capable", dxConnectionEncryptionModeMustEncrypt, connectionId)
+	}
+
+	if len(connection.MacSecKeys) == 0 {
+		return fmt.Errorf("'encryption_mode' cannot be %s: Direct Connect connection (%s) has no MACsec keys; associate a MACsec key with the connection first", dxConnectionEncryptionModeMustEncrypt, connectionId)
+	}
+
+	return nil
 }
 
 func resourceAwsDxConnectionCreate(d *schema.ResourceData, meta interface{}) error {
@@ -75,6 +208,14 @@ func resourceAwsDxConnectionCreate(d *schema.ResourceData, meta interface{}) err
 		Location:       aws.String(d.Get("location").(string)),
 	}
 
+	if v, ok := d.GetOk("lag_id"); ok {
+		req.LagId = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("request_macsec"); ok {
+		req.RequestMACSec = aws.Bool(v.(bool))
+	}
+
 	if len(tags) > 0 {
 		req.Tags = tags.IgnoreAws().DirectconnectTags()
 	}
@@ -133,12 +274,23 @@ func resourceAwsDxConnectionRead(d *schema.ResourceData, meta interface{}) error
 		Resource:  fmt.Sprintf("dxcon/%s", d.Id()),
 	}.String()
 	d.Set("arn", arn)
+	d.Set("partition", meta.(*AWSClient).partition)
 	d.Set("name", connection.ConnectionName)
 	d.Set("bandwidth", connection.Bandwidth)
 	d.Set("location", connection.Location)
 	d.Set("jumbo_frame_capable", connection.JumboFrameCapable)
+	d.Set("lag_id", connection.LagId)
+	if connection.LoaIssueTime != nil {
+		d.Set("loa_issue_time", aws.TimeValue(connection.LoaIssueTime).Format(time.RFC3339))
+	} else {
+		d.Set("loa_issue_time", nil)
+	}
 	d.Set("has_logical_redundancy", connection.HasLogicalRedundancy)
 	d.Set("aws_device", connection.AwsDeviceV2)
+	d.Set("mac_sec_capable", connection.MacSecCapable)
+	d.Set("encryption_mode", connection.EncryptionMode)
+	d.Set("port_encryption_status", connection.PortEncryptionStatus)
+	d.Set("vlan", connection.Vlan)
 
 	tags, err := keyvaluetags.DirectconnectListTags(conn, arn)
 
@@ -146,7 +298,7 @@ func resourceAwsDxConnectionRead(d *schema.ResourceData, meta interface{}) error
 		return fmt.Errorf("error listing tags for Direct Connect connection (%s): %s", arn, err)
 	}
 
-	tags = tags.IgnoreAws().IgnoreConfig(ignoreTagsConfig)
+	tags = dxFilterSystemTags(tags, ignoreTagsConfig)
 
 	//lintignore:AWSR002
 	if err := d.Set("tags", tags.RemoveDefaultConfig(defaultTagsConfig).Map()); err != nil {
@@ -163,6 +315,47 @@ func resourceAwsDxConnectionRead(d *schema.ResourceData, meta interface{}) error
 func resourceAwsDxConnectionUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).dxconn
 
+	if d.HasChange("lag_id") {
+		o, n := d.GetChange("lag_id")
+		oldLagId, newLagId := o.(string), n.(string)
+
+		if oldLagId != "" {
+			if err := dxLagCheckMinimumLinksWithout(conn, oldLagId, d.Id()); err != nil {
+				return err
+			}
+
+			log.Printf("[DEBUG] Disassociating Direct Connect connection (%s) from LAG (%s)", d.Id(), oldLagId)
+			if err := dxConnectionDisassociateFromLag(conn, d.Id(), oldLagId); err != nil {
+				return fmt.Errorf("error disassociating Direct Connect connection (%s) from LAG (%s): %s", d.Id(), oldLagId, err)
+			}
+
+			if err := dxConnectionWaitForLagId(conn, d.Id(), oldLagId, "", d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return fmt.Errorf("error waiting for Direct Connect connection (%s) to be disassociated from LAG (%s): %s", d.Id(), oldLagId, err)
+			}
+		}
+
+		if newLagId != "" {
+			log.Printf("[DEBUG] Associating Direct Connect connection (%s) with LAG (%s)", d.Id(), newLagId)
+			_, err := conn.AssociateConnectionWithLag(&directconnect.AssociateConnectionWithLagInput{
+				ConnectionId: aws.String(d.Id()),
+				LagId:        aws.String(newLagId),
+			})
+			if err != nil {
+				return fmt.Errorf("error associating Direct Connect connection (%s) with LAG (%s): %s", d.Id(), newLagId, err)
+			}
+
+			if err := dxConnectionWaitForLagId(conn, d.Id(), "", newLagId, d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return fmt.Errorf("error waiting for Direct Connect connection (%s) to be associated with LAG (%s): %s", d.Id(), newLagId, err)
+			}
+		}
+	}
+
+	if d.HasChange("encryption_mode") {
+		if err := dxConnectionUpdateEncryptionMode(conn, d.Id(), d.Get("encryption_mode").(string), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
 	arn := d.Get("arn").(string)
 	if d.HasChange("tags_all") {
 		o, n := d.GetChange("tags_all")
@@ -221,6 +414,102 @@ func dxConnectionRefreshStateFunc(conn *directconnect.DirectConnect, connId stri
 	}
 }
 
+// dxConnectionDisassociateFromLag disassociates the connection from the LAG, retrying while the connection is transitioning.
+func dxConnectionDisassociateFromLag(conn *directconnect.DirectConnect, connectionId, lagId string) error {
+	input := &directconnect.DisassociateConnectionFromLagInput{
+		ConnectionId: aws.String(connectionId),
+		LagId:        aws.String(lagId),
+	}
+
+	err := resource.Retry(1*time.Minute, func() *resource.RetryError {
+		_, err := conn.DisassociateConnectionFromLag(input)
+		if err != nil {
+			if isAWSErr(err, directconnect.ErrCodeClientException, "is in a transitioning state.") {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+
+	if isResourceTimeoutError(err) {
+		_, err = conn.DisassociateConnectionFromLag(input)
+	}
+
+	return err
+}
+
+// dxConnectionWaitForLagId waits for the LAG ID of the connection to change from one value to another.
+// An empty LAG ID indicates that the connection is not associated with a LAG.
+func dxConnectionWaitForLagId(conn *directconnect.DirectConnect, connectionId, from, to string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{from},
+		Target:  []string{to},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := conn.DescribeConnections(&directconnect.DescribeConnectionsInput{
+				ConnectionId: aws.String(connectionId),
+			})
+			if err != nil {
+				return nil, "", err
+			}
+			if len(resp.Connections) < 1 {
+				return nil, "", nil
+			}
+
+			return resp.Connections[0], aws.StringValue(resp.Connections[0].LagId), nil
+		},
+		Timeout:    timeout,
+		MinTimeout: 5 * time.Second,
+	}
+
+	_, err := stateConf.WaitForState()
+
+	return err
+}
+
+// dxConnectionUpdateEncryptionMode sets the MACsec encryption mode of the connection and waits for it to take effect.
+// For must_encrypt this includes waiting for the port encryption status to be up, as the connection carries no traffic
+// until it is. should_encrypt and no_encrypt do not disrupt traffic, so only the encryption mode itself is waited on.
+func dxConnectionUpdateEncryptionMode(conn *directconnect.DirectConnect, connectionId, encryptionMode string, timeout time.Duration) error {
+	log.Printf("[DEBUG] Updating Direct Connect connection (%s) encryption mode: %s", connectionId, encryptionMode)
+	_, err := conn.UpdateConnection(&directconnect.UpdateConnectionInput{
+		ConnectionId:   aws.String(connectionId),
+		EncryptionMode: aws.String(encryptionMode),
+	})
+	if err != nil {
+		return fmt.Errorf("error updating Direct Connect connection (%s) encryption mode: %w", connectionId, err)
+	}
+
+	err = resource.Retry(timeout, func() *resource.RetryError {
+		resp, err := conn.DescribeConnections(&directconnect.DescribeConnectionsInput{
+			ConnectionId: aws.String(connectionId),
+		})
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if len(resp.Connections) < 1 {
+			return resource.NonRetryableError(fmt.Errorf("Direct Connect connection (%s) not found", connectionId))
+		}
+
+		connection := resp.Connections[0]
+		if v := aws.StringValue(connection.EncryptionMode); v != encryptionMode {
+			return resource.RetryableError(fmt.Errorf("Direct Connect connection (%s) encryption mode is %s, expected %s", connectionId, v, encryptionMode))
+		}
+		if encryptionMode == dxConnectionEncryptionModeMustEncrypt {
+			if v := aws.StringValue(connection.PortEncryptionStatus); v != dxPortEncryptionStatusUp {
+				return resource.RetryableError(fmt.Errorf("Direct Connect connection (%s) port encryption status is %q, expected %q", connectionId, v, dxPortEncryptionStatusUp))
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for Direct Connect connection (%s) encryption mode to be %s: %w", connectionId, encryptionMode, err)
+	}
+
+	return nil
+}
+
 func isNoSuchDxConnectionErr(err error) bool {
 	return isAWSErr(err, "DirectConnectClientException", "Could not find Connection with ID")
 }
@@ -2,11 +2,9 @@ package aws
 
 import (
 	"fmt"
-	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/directconnect"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -76,25 +74,5 @@ func resourceAwsDxConnectionAssociationRead(d *schema.ResourceData, meta interfa
 func resourceAwsDxConnectionAssociationDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).dxconn
 
-	input := &directconnect.DisassociateConnectionFromLagInput{
-		ConnectionId: aws.String(d.Id()),
-		LagId:        aws.String(d.Get("lag_id").(string)),
-	}
-
-	err := resource.Retry(1*time.Minute, func() *resource.RetryError {
-		_, err := conn.DisassociateConnectionFromLag(input)
-		if err != nil {
-			if isAWSErr(err, directconnect.ErrCodeClientException, "is in a transitioning state.") {
-				return resource.RetryableError(err)
-			}
-			return resource.NonRetryableError(err)
-		}
-		return nil
-	})
-
-	if isResourceTimeoutError(err) {
-		_, err = conn.DisassociateConnectionFromLag(input)
-	}
-
-	return err
+	return dxConnectionDisassociateFromLag(conn, d.Id(), d.Get("lag_id").(string))
 }
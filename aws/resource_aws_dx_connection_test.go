@@ -1,22 +1,30 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/directconnect"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
 )
 
 func init() {
 	resource.AddTestSweepers("aws_dx_connection", &resource.Sweeper{
 		Name: "aws_dx_connection",
 		F:    testSweepDxConnections,
+		Dependencies: []string{
+			"aws_dx_virtual_interface",
+		},
 	})
 }
 
@@ -94,6 +102,7 @@ func TestAccAWSDxConnection_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "name", connectionName),
 					resource.TestCheckResourceAttr(resourceName, "bandwidth", "1Gbps"),
 					resource.TestCheckResourceAttr(resourceName, "location", "EqSe2-EQ"),
+					resource.TestCheckResourceAttr(resourceName, "partition", testAccGetPartition()),
 					resource.TestCheckResourceAttr(resourceName, "tags.%", "0"),
 				),
 			},
@@ -143,6 +152,300 @@ func TestAccAWSDxConnection_tags(t *testing.T) {
 	})
 }
 
+func TestAccAWSDxConnection_LagId(t *testing.T) {
+	connectionName := fmt.Sprintf("tf-dx-%s", acctest.RandString(5))
+	resourceName := "aws_dx_connection.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		ErrorCheck:   testAccErrorCheck(t, directconnect.EndpointsID),
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDxConnectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDxConnectionConfig_lagId(connectionName, "test1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDxConnectionExists(resourceName),
+					resource.TestCheckResourceAttrPair(resourceName, "lag_id", "aws_dx_lag.test1", "id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccDxConnectionConfig_lagId(connectionName, "test2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDxConnectionExists(resourceName),
+					resource.TestCheckResourceAttrPair(resourceName, "lag_id", "aws_dx_lag.test2", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestDxConnectionWaitForLagId(t *testing.T) {
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.Connections:
+			data.Connections = []*directconnect.Connection{{
+				ConnectionId: aws.String("dxcon-12345678"),
+				LagId:        aws.String("dxlag-00000001"),
+			}}
+		}
+	})
+
+	if err := dxConnectionWaitForLagId(conn, "dxcon-12345678", "", "dxlag-00000001", 1*time.Minute); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// The connection has been associated with a different LAG.
+	if err := dxConnectionWaitForLagId(conn, "dxcon-12345678", "", "dxlag-00000002", 1*time.Minute); err == nil {
+		t.Error("expected error, got none")
+	}
+}
+
+func TestDxConnectionUpdateEncryptionMode_mustEncrypt(t *testing.T) {
+	var operations []string
+	describeCalls := 0
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		operations = append(operations, r.Operation.Name)
+
+		switch data := r.Data.(type) {
+		case *directconnect.Connections:
+			describeCalls++
+			connection := &directconnect.Connection{
+				ConnectionId:         aws.String("dxcon-12345678"),
+				EncryptionMode:       aws.String(dxConnectionEncryptionModeMustEncrypt),
+				PortEncryptionStatus: aws.String("Encryption Down"),
+			}
+			// The port encryption status lags the encryption mode.
+			if describeCalls > 1 {
+				connection.PortEncryptionStatus = aws.String(dxPortEncryptionStatusUp)
+			}
+			data.Connections = []*directconnect.Connection{connection}
+		}
+	})
+
+	if err := dxConnectionUpdateEncryptionMode(conn, "dxcon-12345678", dxConnectionEncryptionModeMustEncrypt, 1*time.Minute); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := []string{"UpdateConnection", "DescribeConnections", "DescribeConnections"}; !reflect.DeepEqual(operations, expected) {
+		t.Errorf("expected operations %v, got %v", expected, operations)
+	}
+}
+
+func TestDxConnectionUpdateEncryptionMode_shouldEncrypt(t *testing.T) {
+	var operations []string
+	describeCalls := 0
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		operations = append(operations, r.Operation.Name)
+
+		switch data := r.Data.(type) {
+		case *directconnect.Connections:
+			describeCalls++
+			connection := &directconnect.Connection{
+				ConnectionId:         aws.String("dxcon-12345678"),
+				EncryptionMode:       aws.String(dxConnectionEncryptionModeNoEncrypt),
+				PortEncryptionStatus: aws.String("Encryption Down"),
+			}
+			// The encryption mode is eventually consistent. The port encryption status is not waited on.
+			if describeCalls > 1 {
+				connection.EncryptionMode = aws.String(dxConnectionEncryptionModeShouldEncrypt)
+			}
+			data.Connections = []*directconnect.Connection{connection}
+		}
+	})
+
+	if err := dxConnectionUpdateEncryptionMode(conn, "dxcon-12345678", dxConnectionEncryptionModeShouldEncrypt, 1*time.Minute); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := []string{"UpdateConnection", "DescribeConnections", "DescribeConnections"}; !reflect.DeepEqual(operations, expected) {
+		t.Errorf("expected operations %v, got %v", expected, operations)
+	}
+}
+
+func TestDxConnectionEncryptionModeCustomizeDiff_create(t *testing.T) {
+	r := resourceAwsDxConnection()
+	raw := map[string]interface{}{
+		"bandwidth":      "10Gbps",
+		"location":       "EqDC2",
+		"name":           "test",
+		"request_macsec": true,
+	}
+
+	if _, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), &AWSClient{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	raw["encryption_mode"] = dxConnectionEncryptionModeMustEncrypt
+
+	if _, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), &AWSClient{}); err == nil {
+		t.Fatal("expected error, got none")
+	}
+}
+
+func TestDxConnectionValidateMustEncrypt(t *testing.T) {
+	testCases := []struct {
+		Connection  *directconnect.Connection
+		ExpectError bool
+	}{
+		{
+			Connection: &directconnect.Connection{
+				ConnectionId:  aws.String("dxcon-12345678"),
+				MacSecCapable: aws.Bool(true),
+				MacSecKeys:    []*directconnect.MacSecKey{{Ckn: aws.String("0123456789abcdef")}},
+			},
+		},
+		{
+			Connection: &directconnect.Connection{
+				ConnectionId:  aws.String("dxcon-12345678"),
+				MacSecCapable: aws.Bool(true),
+			},
+			ExpectError: true,
+		},
+		{
+			Connection: &directconnect.Connection{
+				ConnectionId:  aws.String("dxcon-12345678"),
+				MacSecCapable: aws.Bool(false),
+			},
+			ExpectError: true,
+		},
+	}
+
+	for i, testCase := range testCases {
+		err := dxConnectionValidateMustEncrypt(testCase.Connection)
+		if testCase.ExpectError && err == nil {
+			t.Errorf("test case %d: expected error, got none", i)
+		}
+		if !testCase.ExpectError && err != nil {
+			t.Errorf("test case %d: unexpected error: %s", i, err)
+		}
+	}
+}
+
+func TestDxConnectionRead_loaIssueTime(t *testing.T) {
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.Connections:
+			data.Connections = []*directconnect.Connection{{
+				ConnectionId:    aws.String("dxcon-12345678"),
+				ConnectionState: aws.String(directconnect.ConnectionStateAvailable),
+				LoaIssueTime:    aws.Time(time.Date(2021, time.June, 1, 12, 30, 0, 0, time.UTC)),
+			}}
+		case *directconnect.DescribeTagsOutput:
+			data.ResourceTags = []*directconnect.ResourceTag{{}}
+		}
+	})
+
+	d := resourceAwsDxConnection().Data(nil)
+	d.SetId("dxcon-12345678")
+
+	if err := resourceAwsDxConnectionRead(d, &AWSClient{dxconn: conn, partition: "aws", region: "us-west-2", accountid: "123456789012"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, expected := d.Get("loa_issue_time").(string), "2021-06-01T12:30:00Z"; got != expected {
+		t.Errorf("got loa_issue_time %q, expected %q", got, expected)
+	}
+}
+
+func TestDxConnectionRead_partition(t *testing.T) {
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.Connections:
+			data.Connections = []*directconnect.Connection{{
+				ConnectionId:    aws.String("dxcon-12345678"),
+				ConnectionState: aws.String(directconnect.ConnectionStateAvailable),
+			}}
+		case *directconnect.DescribeTagsOutput:
+			data.ResourceTags = []*directconnect.ResourceTag{{}}
+		}
+	})
+
+	d := resourceAwsDxConnection().Data(nil)
+	d.SetId("dxcon-12345678")
+
+	if err := resourceAwsDxConnectionRead(d, &AWSClient{dxconn: conn, partition: "aws-us-gov", region: "us-gov-west-1", accountid: "123456789012"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, expected := d.Get("partition").(string), "aws-us-gov"; got != expected {
+		t.Errorf("got partition %q, expected %q", got, expected)
+	}
+	if got, expected := d.Get("arn").(string), "arn:aws-us-gov:directconnect:us-gov-west-1:123456789012:dxcon/dxcon-12345678"; got != expected {
+		t.Errorf("got arn %q, expected %q", got, expected)
+	}
+}
+
+func TestDxConnectionRead_systemTags(t *testing.T) {
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.Connections:
+			data.Connections = []*directconnect.Connection{{
+				ConnectionId:    aws.String("dxcon-12345678"),
+				ConnectionState: aws.String(directconnect.ConnectionStateAvailable),
+			}}
+		case *directconnect.DescribeTagsOutput:
+			data.ResourceTags = []*directconnect.ResourceTag{{
+				Tags: keyvaluetags.New(map[string]string{
+					"aws:cloudformation:stack-name": "stack",
+					"Environment":                   "production",
+					"Ignored":                       "ignored",
+					"Name":                          "tf-dx-connection",
+				}).DirectconnectTags(),
+			}}
+		}
+	})
+
+	d := resourceAwsDxConnection().Data(nil)
+	d.SetId("dxcon-12345678")
+
+	client := &AWSClient{
+		accountid:         "123456789012",
+		DefaultTagsConfig: &keyvaluetags.DefaultConfig{Tags: keyvaluetags.New(map[string]string{"Environment": "production"})},
+		dxconn:            conn,
+		IgnoreTagsConfig:  &keyvaluetags.IgnoreConfig{Keys: keyvaluetags.New([]string{"Ignored"})},
+		partition:         "aws",
+		region:            "us-west-2",
+	}
+
+	if err := resourceAwsDxConnectionRead(d, client); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, expected := d.Get("tags").(map[string]interface{}), map[string]interface{}{"Name": "tf-dx-connection"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("got tags %v, expected %v", got, expected)
+	}
+	if got, expected := d.Get("tags_all").(map[string]interface{}), map[string]interface{}{"Environment": "production", "Name": "tf-dx-connection"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("got tags_all %v, expected %v", got, expected)
+	}
+}
+
+func TestDxFilterSystemTags(t *testing.T) {
+	tags := keyvaluetags.New(map[string]string{
+		"aws:cloudformation:stack-name": "stack",
+		"Ignored":                       "ignored",
+		"IgnoredPrefix:key":             "ignored",
+		"Name":                          "name",
+	})
+	ignoreTagsConfig := &keyvaluetags.IgnoreConfig{
+		Keys:        keyvaluetags.New([]string{"Ignored"}),
+		KeyPrefixes: keyvaluetags.New([]string{"IgnoredPrefix:"}),
+	}
+
+	if got, expected := dxFilterSystemTags(tags, ignoreTagsConfig).Map(), map[string]string{"Name": "name"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, expected %v", got, expected)
+	}
+
+	if got, expected := dxFilterSystemTags(tags, nil).Map(), map[string]string{"Ignored": "ignored", "IgnoredPrefix:key": "ignored", "Name": "name"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("without ignore_tags configuration: got %v, expected %v", got, expected)
+	}
+}
+
 func testAccCheckAwsDxConnectionDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).dxconn
 
@@ -217,3 +520,28 @@ resource "aws_dx_connection" "test" {
 }
 `, n)
 }
+
+func testAccDxConnectionConfig_lagId(n, lagResourceName string) string {
+	return fmt.Sprintf(`
+resource "aws_dx_lag" "test1" {
+  name                  = "%[1]s-1"
+  connections_bandwidth = "1Gbps"
+  location              = "EqSe2-EQ"
+  force_destroy         = true
+}
+
+resource "aws_dx_lag" "test2" {
+  name                  = "%[1]s-2"
+  connections_bandwidth = "1Gbps"
+  location              = "EqSe2-EQ"
+  force_destroy         = true
+}
+
+resource "aws_dx_connection" "test" {
+  name      = %[1]q
+  bandwidth = "1Gbps"
+  location  = "EqSe2-EQ"
+  lag_id    = aws_dx_lag.%[2]s.id
+}
+`, n, lagResourceName)
+}
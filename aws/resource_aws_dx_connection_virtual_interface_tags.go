@@ -0,0 +1,191 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+)
+
+func resourceAwsDxConnectionVirtualInterfaceTags() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsDxConnectionVirtualInterfaceTagsCreate,
+		Read:   resourceAwsDxConnectionVirtualInterfaceTagsRead,
+		Update: resourceAwsDxConnectionVirtualInterfaceTagsUpdate,
+		Delete: resourceAwsDxConnectionVirtualInterfaceTagsDelete,
+
+		Schema: map[string]*schema.Schema{
+			"connection_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"virtual_interface_ids": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceAwsDxConnectionVirtualInterfaceTagsCreate(d *schema.ResourceData, meta interface{}) error {
+	connectionId := d.Get("connection_id").(string)
+
+	if err := dxConnectionVirtualInterfaceTagsUpdate(meta, connectionId, nil, d.Get("tags").(map[string]interface{})); err != nil {
+		return err
+	}
+
+	d.SetId(connectionId)
+
+	return resourceAwsDxConnectionVirtualInterfaceTagsRead(d, meta)
+}
+
+func resourceAwsDxConnectionVirtualInterfaceTagsRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dxconn
+
+	vifs, err := dxConnectionOwnVirtualInterfaces(meta, d.Id())
+	if err != nil {
+		return err
+	}
+
+	// A managed tag is only reported if every virtual interface on the connection carries it.
+	// Any virtual interface that is missing a tag, or on which it has been changed, causes a diff.
+	tags := keyvaluetags.New(d.Get("tags").(map[string]interface{}))
+	vifIds := make([]string, 0, len(vifs))
+	for _, vif := range vifs {
+		vifArn := dxVirtualInterfaceArn(meta.(*AWSClient), aws.StringValue(vif.OwnerAccount), aws.StringValue(vif.VirtualInterfaceId))
+
+		vifTags, err := keyvaluetags.DirectconnectListTags(conn, vifArn)
+		if err != nil {
+			return fmt.Errorf("error listing tags for Direct Connect virtual interface (%s): %w", vifArn, err)
+		}
+
+		tags = dxConnectionVirtualInterfaceTagsApplied(vifTags, tags)
+		vifIds = append(vifIds, aws.StringValue(vif.VirtualInterfaceId))
+	}
+
+	d.Set("connection_id", d.Id())
+
+	if err := d.Set("tags", tags.Map()); err != nil {
+		return fmt.Errorf("error setting tags: %w", err)
+	}
+
+	if err := d.Set("virtual_interface_ids", vifIds); err != nil {
+		return fmt.Errorf("error setting virtual_interface_ids: %w", err)
+	}
+
+	return nil
+}
+
+func resourceAwsDxConnectionVirtualInterfaceTagsUpdate(d *schema.ResourceData, meta interface{}) error {
+	o, n := d.GetChange("tags")
+
+	if err := dxConnectionVirtualInterfaceTagsUpdate(meta, d.Id(), o.(map[string]interface{}), n.(map[string]interface{})); err != nil {
+		return err
+	}
+
+	return resourceAwsDxConnectionVirtualInterfaceTagsRead(d, meta)
+}
+
+func resourceAwsDxConnectionVirtualInterfaceTagsDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dxconn
+
+	vifs, err := dxConnectionOwnVirtualInterfaces(meta, d.Id())
+	if err != nil {
+		return err
+	}
+
+	tags := keyvaluetags.New(d.Get("tags").(map[string]interface{}))
+	for _, vif := range vifs {
+		vifArn := dxVirtualInterfaceArn(meta.(*AWSClient), aws.StringValue(vif.OwnerAccount), aws.StringValue(vif.VirtualInterfaceId))
+
+		vifTags, err := keyvaluetags.DirectconnectListTags(conn, vifArn)
+		if err != nil {
+			return fmt.Errorf("error listing tags for Direct Connect virtual interface (%s): %w", vifArn, err)
+		}
+
+		// Tags whose values have since been changed elsewhere are left in place.
+		removeTags := dxConnectionVirtualInterfaceTagsApplied(vifTags, tags)
+		if len(removeTags) == 0 {
+			continue
+		}
+
+		log.Printf("[DEBUG] Removing tags from Direct Connect virtual interface (%s): %s", vifArn, removeTags)
+		if err := keyvaluetags.DirectconnectUpdateTags(conn, vifArn, removeTags.Map(), nil); err != nil {
+			return fmt.Errorf("error removing tags from Direct Connect virtual interface (%s): %w", vifArn, err)
+		}
+	}
+
+	return nil
+}
+
+// dxConnectionVirtualInterfaceTagsUpdate applies the change from oldTags to newTags to every virtual interface on a connection.
+// Only the keys in oldTags and newTags are considered, so tags managed elsewhere are left untouched.
+func dxConnectionVirtualInterfaceTagsUpdate(meta interface{}, connectionId string, oldTags, newTags map[string]interface{}) error {
+	conn := meta.(*AWSClient).dxconn
+
+	vifs, err := dxConnectionOwnVirtualInterfaces(meta, connectionId)
+	if err != nil {
+		return err
+	}
+
+	managedKeys := keyvaluetags.New(oldTags).Merge(keyvaluetags.New(newTags))
+	for _, vif := range vifs {
+		vifArn := dxVirtualInterfaceArn(meta.(*AWSClient), aws.StringValue(vif.OwnerAccount), aws.StringValue(vif.VirtualInterfaceId))
+
+		vifTags, err := keyvaluetags.DirectconnectListTags(conn, vifArn)
+		if err != nil {
+			return fmt.Errorf("error listing tags for Direct Connect virtual interface (%s): %w", vifArn, err)
+		}
+
+		if err := keyvaluetags.DirectconnectUpdateTags(conn, vifArn, vifTags.Only(managedKeys).Map(), newTags); err != nil {
+			return fmt.Errorf("error updating tags for Direct Connect virtual interface (%s): %w", vifArn, err)
+		}
+	}
+
+	return nil
+}
+
+// dxConnectionOwnVirtualInterfaces returns the virtual interfaces on a connection or LAG that are owned by the caller's account.
+// Hosted virtual interfaces allocated to other accounts cannot be tagged by the connection owner.
+func dxConnectionOwnVirtualInterfaces(meta interface{}, connectionId string) ([]*directconnect.VirtualInterface, error) {
+	vifs, err := dxConnectionVirtualInterfaces(meta.(*AWSClient).dxconn, connectionId)
+	if err != nil {
+		return nil, err
+	}
+
+	accountId := meta.(*AWSClient).accountid
+	result := make([]*directconnect.VirtualInterface, 0, len(vifs))
+	for _, vif := range vifs {
+		if ownerAccount := aws.StringValue(vif.OwnerAccount); ownerAccount != accountId {
+			log.Printf("[DEBUG] Skipping Direct Connect virtual interface (%s) owned by account %s", aws.StringValue(vif.VirtualInterfaceId), ownerAccount)
+			continue
+		}
+
+		result = append(result, vif)
+	}
+
+	return result, nil
+}
+
+// dxConnectionVirtualInterfaceTagsApplied returns those of the managed tags that are present, with the same value, in the virtual interface's tags.
+func dxConnectionVirtualInterfaceTagsApplied(vifTags, managedTags keyvaluetags.KeyValueTags) keyvaluetags.KeyValueTags {
+	result := make(keyvaluetags.KeyValueTags)
+
+	for k, v := range managedTags {
+		if vifV, ok := vifTags[k]; ok && vifV.Equal(v) {
+			result[k] = v
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,132 @@
+package aws
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDxConnectionVirtualInterfaceTagsDelete_onlyAppliedTags(t *testing.T) {
+	var untagKeys []string
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				OwnerAccount:          aws.String("123456789012"),
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+			}}
+		case *directconnect.DescribeTagsOutput:
+			data.ResourceTags = []*directconnect.ResourceTag{{
+				Tags: []*directconnect.Tag{
+					// Changed outside of Terraform.
+					{Key: aws.String("Environment"), Value: aws.String("dev")},
+					// Managed elsewhere.
+					{Key: aws.String("Owner"), Value: aws.String("net")},
+					{Key: aws.String("Team"), Value: aws.String("net")},
+				},
+			}}
+		case *directconnect.UntagResourceOutput:
+			untagKeys = aws.StringValueSlice(r.Params.(*directconnect.UntagResourceInput).TagKeys)
+		}
+	})
+
+	d := resourceAwsDxConnectionVirtualInterfaceTags().Data(nil)
+	d.SetId("dxcon-12345678")
+	d.Set("tags", map[string]string{"Environment": "prod", "Team": "net"})
+
+	if err := resourceAwsDxConnectionVirtualInterfaceTagsDelete(d, &AWSClient{accountid: "123456789012", dxconn: conn, partition: "aws", region: "us-west-2"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := []string{"Team"}; !reflect.DeepEqual(untagKeys, expected) {
+		t.Errorf("expected tag keys %v to be removed, got %v", expected, untagKeys)
+	}
+}
+
+func TestDxConnectionOwnVirtualInterfaces(t *testing.T) {
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{
+				{
+					OwnerAccount:          aws.String("123456789012"),
+					VirtualInterfaceId:    aws.String("dxvif-00000001"),
+					VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+				},
+				{
+					// Hosted virtual interface allocated to another account.
+					OwnerAccount:          aws.String("210987654321"),
+					VirtualInterfaceId:    aws.String("dxvif-00000002"),
+					VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+				},
+			}
+		}
+	})
+
+	vifs, err := dxConnectionOwnVirtualInterfaces(&AWSClient{accountid: "123456789012", dxconn: conn}, "dxcon-12345678")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(vifs) != 1 || aws.StringValue(vifs[0].VirtualInterfaceId) != "dxvif-00000001" {
+		t.Errorf("expected only dxvif-00000001, got %v", vifs)
+	}
+}
+
+func TestAccAwsDxConnectionVirtualInterfaceTags_basic(t *testing.T) {
+	key := "DX_CONNECTION_ID"
+	connectionId := os.Getenv(key)
+	if connectionId == "" {
+		t.Skipf("Environment variable %s is not set", key)
+	}
+
+	rName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "aws_dx_connection_virtual_interface_tags.test"
+	vifResourceName := "aws_dx_private_virtual_interface.test"
+	bgpAsn := acctest.RandIntRange(64512, 65534)
+	vlan := acctest.RandIntRange(2049, 4094)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:   func() { testAccPreCheck(t) },
+		ErrorCheck: testAccErrorCheck(t, directconnect.EndpointsID),
+		Providers:  testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDxConnectionVirtualInterfaceTagsConfig(connectionId, rName, "prod", bgpAsn, vlan),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "connection_id", connectionId),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Environment", "prod"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "virtual_interface_ids.*", vifResourceName, "id"),
+				),
+			},
+			{
+				Config: testAccDxConnectionVirtualInterfaceTagsConfig(connectionId, rName, "test", bgpAsn, vlan),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Environment", "test"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDxConnectionVirtualInterfaceTagsConfig(cid, rName, environment string, bgpAsn, vlan int) string {
+	return testAccDxPrivateVirtualInterfaceConfig_basic(cid, rName, bgpAsn, vlan) + fmt.Sprintf(`
+resource "aws_dx_connection_virtual_interface_tags" "test" {
+  connection_id = aws_dx_private_virtual_interface.test.connection_id
+
+  tags = {
+    Environment = %[1]q
+  }
+}
+`, environment)
+}
@@ -149,6 +149,19 @@ func dxGatewayStateRefresh(conn *directconnect.DirectConnect, dxgwId string) res
 	}
 }
 
+// dxGatewayAmazonSideAsn returns the Amazon side ASN of a Direct Connect gateway, or zero if the gateway cannot be found.
+func dxGatewayAmazonSideAsn(conn *directconnect.DirectConnect, dxgwId string) (int64, error) {
+	dxgwRaw, state, err := dxGatewayStateRefresh(conn, dxgwId)()
+	if err != nil {
+		return 0, fmt.Errorf("error reading Direct Connect gateway (%s): %w", dxgwId, err)
+	}
+	if state == directconnect.GatewayStateDeleted {
+		return 0, nil
+	}
+
+	return aws.Int64Value(dxgwRaw.(*directconnect.Gateway).AmazonSideAsn), nil
+}
+
 func waitForDirectConnectGatewayDeletion(conn *directconnect.DirectConnect, gatewayID string, timeout time.Duration) error {
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{directconnect.GatewayStatePending, directconnect.GatewayStateAvailable, directconnect.GatewayStateDeleting},
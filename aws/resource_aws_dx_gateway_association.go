@@ -1,21 +1,42 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
 	gatewayAssociationStateDeleted = "deleted"
+
+	gatewayAssociationMethodDirect   = "direct"
+	gatewayAssociationMethodProposal = "proposal"
+
+	dxGatewayAssociationCheckLimitsModeError = "error"
+	dxGatewayAssociationCheckLimitsModeWarn  = "warn"
+
+	// Default quotas, see https://docs.aws.amazon.com/directconnect/latest/UserGuide/limits.html.
+	// These can be raised via AWS Support so the preflight check is best-effort only.
+	dxGatewayMaxTransitGatewayAssociations        = 3
+	dxGatewayMaxVirtualPrivateGatewayAssociations = 10
+	dxGatewayMaxTransitGatewayAssociationPrefixes = 20
 )
 
+var dxGatewayAssociationCheckLimitsModes = []string{
+	dxGatewayAssociationCheckLimitsModeError,
+	dxGatewayAssociationCheckLimitsModeWarn,
+}
+
 func resourceAwsDxGatewayAssociation() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceAwsDxGatewayAssociationCreate,
@@ -40,7 +61,21 @@ func resourceAwsDxGatewayAssociation() *schema.Resource {
 				Type:     schema.TypeSet,
 				Optional: true,
 				Computed: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDR,
+				},
+			},
+
+			"allowed_prefixes_address_family": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(directconnect.AddressFamily_Values(), false),
+			},
+
+			"allowed_prefixes_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
 			},
 
 			"associated_gateway_id": {
@@ -65,6 +100,17 @@ func resourceAwsDxGatewayAssociation() *schema.Resource {
 				Computed: true,
 			},
 
+			"association_method": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"check_limits": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(dxGatewayAssociationCheckLimitsModes, false),
+			},
+
 			"dx_gateway_association_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -87,6 +133,12 @@ func resourceAwsDxGatewayAssociation() *schema.Resource {
 				ConflictsWith: []string{"associated_gateway_id", "vpn_gateway_id"},
 			},
 
+			"requested_allowed_prefixes": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
 			"vpn_gateway_id": {
 				Type:          schema.TypeString,
 				Optional:      true,
@@ -101,6 +153,11 @@ func resourceAwsDxGatewayAssociation() *schema.Resource {
 			Update: schema.DefaultTimeout(30 * time.Minute),
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
+
+		CustomizeDiff: customdiff.Sequence(
+			resourceAwsDxGatewayAssociationCustomizeDiff,
+			dxGatewayAssociationCheckLimitsCustomizeDiff,
+		),
 	}
 }
 
@@ -122,6 +179,29 @@ func resourceAwsDxGatewayAssociationCreate(d *schema.ResourceData, meta interfac
 	}
 
 	associationId := ""
+	if gwAcctIdOk {
+		// Record the prefixes requested by the proposal before accepting it, as accepting with overridden prefixes changes them.
+		proposal, err := describeDirectConnectGatewayAssociationProposal(conn, proposalIdRaw.(string))
+		if err != nil {
+			return fmt.Errorf("error reading Direct Connect gateway association proposal (%s): %s", proposalIdRaw.(string), err)
+		}
+		if proposal != nil {
+			if err := d.Set("requested_allowed_prefixes", flattenDirectConnectGatewayAssociationProposalAllowedPrefixes(proposal.RequestedAllowedPrefixesToDirectConnectGateway)); err != nil {
+				return fmt.Errorf("error setting requested_allowed_prefixes: %s", err)
+			}
+		}
+
+		// A proposal for a gateway in the caller's own account needn't be accepted, the association is created directly.
+		if gwAcctIdRaw.(string) == meta.(*AWSClient).accountid {
+			if proposal == nil || proposal.AssociatedGateway == nil {
+				return fmt.Errorf("Direct Connect gateway association proposal (%s) not found", proposalIdRaw.(string))
+			}
+
+			gwIdRaw = aws.StringValue(proposal.AssociatedGateway.Id)
+			gwAcctIdOk = false
+		}
+	}
+
 	if gwAcctIdOk {
 		req := &directconnect.AcceptDirectConnectGatewayAssociationProposalInput{
 			AssociatedGatewayOwnerAccount:                 aws.String(gwAcctIdRaw.(string)),
@@ -139,11 +219,18 @@ func resourceAwsDxGatewayAssociationCreate(d *schema.ResourceData, meta interfac
 		// For historical reasons the resource ID isn't set to the association ID returned from the API.
 		associationId = aws.StringValue(resp.DirectConnectGatewayAssociation.AssociationId)
 		d.SetId(dxGatewayAssociationId(dxgwId, aws.StringValue(resp.DirectConnectGatewayAssociation.AssociatedGateway.Id)))
+		d.Set("association_method", gatewayAssociationMethodProposal)
 	} else {
 		gwId := gwIdRaw.(string)
 
+		allowedPrefixes := d.Get("allowed_prefixes").(*schema.Set)
+		if allowedPrefixes.Len() == 0 && proposalIdOk {
+			// Without configured prefixes, associate with those requested by the proposal.
+			allowedPrefixes = d.Get("requested_allowed_prefixes").(*schema.Set)
+		}
+
 		req := &directconnect.CreateDirectConnectGatewayAssociationInput{
-			AddAllowedPrefixesToDirectConnectGateway: expandDxRouteFilterPrefixes(d.Get("allowed_prefixes").(*schema.Set)),
+			AddAllowedPrefixesToDirectConnectGateway: expandDxRouteFilterPrefixes(allowedPrefixes),
 			DirectConnectGatewayId:                   aws.String(dxgwId),
 			GatewayId:                                aws.String(gwId),
 		}
@@ -157,6 +244,7 @@ func resourceAwsDxGatewayAssociationCreate(d *schema.ResourceData, meta interfac
 		// For historical reasons the resource ID isn't set to the association ID returned from the API.
 		associationId = aws.StringValue(resp.DirectConnectGatewayAssociation.AssociationId)
 		d.SetId(dxGatewayAssociationId(dxgwId, gwId))
+		d.Set("association_method", gatewayAssociationMethodDirect)
 	}
 	d.Set("dx_gateway_association_id", associationId)
 
@@ -183,14 +271,24 @@ func resourceAwsDxGatewayAssociationRead(d *schema.ResourceData, meta interface{
 
 	assoc := assocRaw.(*directconnect.GatewayAssociation)
 
-	err = d.Set("allowed_prefixes", flattenDxRouteFilterPrefixes(assoc.AllowedPrefixesToDirectConnectGateway))
+	allowedPrefixes := flattenDxRouteFilterPrefixes(assoc.AllowedPrefixesToDirectConnectGateway)
+	err = d.Set("allowed_prefixes", allowedPrefixes)
 	if err != nil {
 		return fmt.Errorf("error setting allowed_prefixes: %s", err)
 	}
+	d.Set("allowed_prefixes_count", allowedPrefixes.Len())
 
 	d.Set("associated_gateway_id", assoc.AssociatedGateway.Id)
 	d.Set("associated_gateway_owner_account_id", assoc.AssociatedGateway.OwnerAccount)
 	d.Set("associated_gateway_type", assoc.AssociatedGateway.Type)
+	// The method is recorded on create. Otherwise, e.g. after import, infer it: cross-account associations can only be created by accepting an association proposal.
+	if d.Get("association_method").(string) == "" {
+		if aws.StringValue(assoc.AssociatedGateway.OwnerAccount) != aws.StringValue(assoc.DirectConnectGatewayOwnerAccount) {
+			d.Set("association_method", gatewayAssociationMethodProposal)
+		} else {
+			d.Set("association_method", gatewayAssociationMethodDirect)
+		}
+	}
 	d.Set("dx_gateway_association_id", assoc.AssociationId)
 	d.Set("dx_gateway_id", assoc.DirectConnectGatewayId)
 	d.Set("dx_gateway_owner_account_id", assoc.DirectConnectGatewayOwnerAccount)
@@ -284,6 +382,165 @@ func resourceAwsDxGatewayAssociationImport(d *schema.ResourceData, meta interfac
 	return []*schema.ResourceData{d}, nil
 }
 
+func resourceAwsDxGatewayAssociationCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	addressFamily := diff.Get("allowed_prefixes_address_family").(string)
+	if addressFamily == "" || !diff.NewValueKnown("allowed_prefixes") {
+		return nil
+	}
+
+	return dxGatewayAssociationValidateAllowedPrefixesAddressFamily(addressFamily, expandStringSet(diff.Get("allowed_prefixes").(*schema.Set)))
+}
+
+// dxGatewayAssociationCheckLimitsCustomizeDiff checks, on create and if 'check_limits' is configured,
+// whether the new association would exceed the Direct Connect gateway's default quotas.
+// The check is best-effort: errors looking up the gateway's existing associations are logged and ignored.
+func dxGatewayAssociationCheckLimitsCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	mode := diff.Get("check_limits").(string)
+	if mode == "" || diff.Id() != "" || !diff.NewValueKnown("dx_gateway_id") {
+		return nil
+	}
+
+	conn := meta.(*AWSClient).dxconn
+	dxgwId := diff.Get("dx_gateway_id").(string)
+
+	gwType := ""
+	if v, ok := diff.GetOk("associated_gateway_id"); ok {
+		gwType = dxGatewayAssociationGatewayTypeOf(v.(string))
+	} else if v, ok := diff.GetOk("proposal_id"); ok {
+		resp, err := conn.DescribeDirectConnectGatewayAssociationProposals(&directconnect.DescribeDirectConnectGatewayAssociationProposalsInput{
+			ProposalId: aws.String(v.(string)),
+		})
+		if err != nil {
+			log.Printf("[WARN] Skipping Direct Connect gateway (%s) association limits check, error reading proposal (%s): %s", dxgwId, v.(string), err)
+			return nil
+		}
+		if len(resp.DirectConnectGatewayAssociationProposals) == 1 && resp.DirectConnectGatewayAssociationProposals[0].AssociatedGateway != nil {
+			gwType = aws.StringValue(resp.DirectConnectGatewayAssociationProposals[0].AssociatedGateway.Type)
+		}
+	}
+	if gwType == "" {
+		log.Printf("[WARN] Skipping Direct Connect gateway (%s) association limits check, unable to determine associated gateway type", dxgwId)
+		return nil
+	}
+
+	allowedPrefixes := -1
+	if diff.NewValueKnown("allowed_prefixes") {
+		if v, ok := diff.GetOk("allowed_prefixes"); ok {
+			allowedPrefixes = v.(*schema.Set).Len()
+		}
+	}
+
+	violations, err := dxGatewayAssociationCheckLimits(conn, dxgwId, gwType, allowedPrefixes)
+	if err != nil {
+		log.Printf("[WARN] Skipping Direct Connect gateway (%s) association limits check: %s", dxgwId, err)
+		return nil
+	}
+
+	for _, violation := range violations {
+		if mode == dxGatewayAssociationCheckLimitsModeError {
+			return fmt.Errorf("%s. If the quota has been raised for your account, remove 'check_limits' or set it to %q", violation, dxGatewayAssociationCheckLimitsModeWarn)
+		}
+
+		log.Printf("[WARN] %s", violation)
+	}
+
+	return nil
+}
+
+// dxGatewayAssociationCheckLimits returns a description of each default quota that would be exceeded
+// by associating a gateway of the specified type with the Direct Connect gateway.
+// A negative allowedPrefixes skips the allowed prefixes check.
+func dxGatewayAssociationCheckLimits(conn *directconnect.DirectConnect, dxgwId, gwType string, allowedPrefixes int) ([]string, error) {
+	input := &directconnect.DescribeDirectConnectGatewayAssociationsInput{
+		DirectConnectGatewayId: aws.String(dxgwId),
+	}
+
+	count := 0
+	for {
+		output, err := conn.DescribeDirectConnectGatewayAssociations(input)
+		if err != nil {
+			return nil, fmt.Errorf("error listing Direct Connect gateway (%s) associations: %w", dxgwId, err)
+		}
+
+		for _, assoc := range output.DirectConnectGatewayAssociations {
+			if assoc == nil || assoc.AssociatedGateway == nil {
+				continue
+			}
+
+			switch aws.StringValue(assoc.AssociationState) {
+			case directconnect.GatewayAssociationStateDisassociating, directconnect.GatewayAssociationStateDisassociated:
+				continue
+			}
+
+			if aws.StringValue(assoc.AssociatedGateway.Type) == gwType {
+				count++
+			}
+		}
+
+		if aws.StringValue(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	var violations []string
+
+	switch gwType {
+	case directconnect.GatewayTypeTransitGateway:
+		if count >= dxGatewayMaxTransitGatewayAssociations {
+			violations = append(violations, fmt.Sprintf("Direct Connect gateway (%s) already has %d transit gateway associations, the default quota is %d", dxgwId, count, dxGatewayMaxTransitGatewayAssociations))
+		}
+		if allowedPrefixes > dxGatewayMaxTransitGatewayAssociationPrefixes {
+			violations = append(violations, fmt.Sprintf("%d allowed prefixes configured for transit gateway association with Direct Connect gateway (%s), the default quota is %d", allowedPrefixes, dxgwId, dxGatewayMaxTransitGatewayAssociationPrefixes))
+		}
+	case directconnect.GatewayTypeVirtualPrivateGateway:
+		if count >= dxGatewayMaxVirtualPrivateGatewayAssociations {
+			violations = append(violations, fmt.Sprintf("Direct Connect gateway (%s) already has %d virtual private gateway associations, the default quota is %d", dxgwId, count, dxGatewayMaxVirtualPrivateGatewayAssociations))
+		}
+	}
+
+	return violations, nil
+}
+
+// dxGatewayAssociationGatewayTypeOf returns the Direct Connect gateway type of the VGW or transit gateway ID.
+func dxGatewayAssociationGatewayTypeOf(gwId string) string {
+	switch {
+	case strings.HasPrefix(gwId, "tgw-"):
+		return directconnect.GatewayTypeTransitGateway
+	case strings.HasPrefix(gwId, "vgw-"):
+		return directconnect.GatewayTypeVirtualPrivateGateway
+	}
+
+	return ""
+}
+
+// dxGatewayAssociationValidateAllowedPrefixesAddressFamily returns an error if any of the allowed prefixes is not of the specified address family.
+func dxGatewayAssociationValidateAllowedPrefixesAddressFamily(addressFamily string, allowedPrefixes []*string) error {
+	for _, v := range allowedPrefixes {
+		prefix := aws.StringValue(v)
+
+		ip, _, err := net.ParseCIDR(prefix)
+		if err != nil {
+			return fmt.Errorf("allowed prefix (%s) is not a valid CIDR: %w", prefix, err)
+		}
+
+		if family := dxAddressFamilyOf(ip); family != addressFamily {
+			return fmt.Errorf("allowed prefix (%s) is an %s prefix, but 'allowed_prefixes_address_family' is %s", prefix, family, addressFamily)
+		}
+	}
+
+	return nil
+}
+
+// dxAddressFamilyOf returns the Direct Connect address family of the IP address.
+func dxAddressFamilyOf(ip net.IP) string {
+	if ip.To4() != nil {
+		return directconnect.AddressFamilyIpv4
+	}
+
+	return directconnect.AddressFamilyIpv6
+}
+
 func dxGatewayAssociationStateRefresh(conn *directconnect.DirectConnect, associationId string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		resp, err := conn.DescribeDirectConnectGatewayAssociations(&directconnect.DescribeDirectConnectGatewayAssociationsInput{
@@ -308,7 +565,10 @@ func dxGatewayAssociationStateRefresh(conn *directconnect.DirectConnect, associa
 				log.Printf("[INFO] Direct Connect gateway association (%s) state change error: %s", id, stateChangeError)
 			}
 
-			return assoc, aws.StringValue(assoc.AssociationState), nil
+			state := aws.StringValue(assoc.AssociationState)
+			log.Printf("[DEBUG] Direct Connect gateway association (%s) state: %s", associationId, state)
+
+			return assoc, state, nil
 
 		default:
 			return nil, "", fmt.Errorf("Found %d Direct Connect gateway associations for %s, expected 1", n, associationId)
@@ -337,20 +597,26 @@ func waitForDirectConnectGatewayAssociationAvailabilityOnCreate(conn *directconn
 }
 
 func waitForDirectConnectGatewayAssociationAvailabilityOnUpdate(conn *directconnect.DirectConnect, associationId string, timeout time.Duration) error {
-	stateConf := &resource.StateChangeConf{
-		Pending:    []string{directconnect.GatewayAssociationStateUpdating},
-		Target:     []string{directconnect.GatewayAssociationStateAssociated},
-		Refresh:    dxGatewayAssociationStateRefresh(conn, associationId),
-		Timeout:    timeout,
-		Delay:      10 * time.Second,
-		MinTimeout: 5 * time.Second,
-	}
-
-	_, err := stateConf.WaitForState()
+	_, err := dxGatewayAssociationUpdateStateConf(conn, associationId, timeout).WaitForState()
 
 	return err
 }
 
+// dxGatewayAssociationUpdateStateConf returns the StateChangeConf used to wait for an association update to converge.
+// The association may still be reported as associated before it transitions to updating,
+// so the associated state must be seen on consecutive refreshes.
+func dxGatewayAssociationUpdateStateConf(conn *directconnect.DirectConnect, associationId string, timeout time.Duration) *resource.StateChangeConf {
+	return &resource.StateChangeConf{
+		Pending:                   []string{directconnect.GatewayAssociationStateUpdating},
+		Target:                    []string{directconnect.GatewayAssociationStateAssociated},
+		Refresh:                   dxGatewayAssociationStateRefresh(conn, associationId),
+		Timeout:                   timeout,
+		Delay:                     10 * time.Second,
+		MinTimeout:                5 * time.Second,
+		ContinuousTargetOccurence: 2,
+	}
+}
+
 func waitForDirectConnectGatewayAssociationDeletion(conn *directconnect.DirectConnect, associationId string, timeout time.Duration) error {
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{directconnect.GatewayAssociationStateDisassociating},
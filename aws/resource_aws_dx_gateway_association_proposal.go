@@ -15,9 +15,10 @@ func resourceAwsDxGatewayAssociationProposal() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceAwsDxGatewayAssociationProposalCreate,
 		Read:   resourceAwsDxGatewayAssociationProposalRead,
+		Update: schema.Noop,
 		Delete: resourceAwsDxGatewayAssociationProposalDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceAwsDxGatewayAssociationProposalImport,
 		},
 
 		CustomizeDiff: customdiff.Sequence(
@@ -37,6 +38,11 @@ func resourceAwsDxGatewayAssociationProposal() *schema.Resource {
 		),
 
 		Schema: map[string]*schema.Schema{
+			"accepted_allowed_prefixes": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			"allowed_prefixes": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -67,6 +73,20 @@ func resourceAwsDxGatewayAssociationProposal() *schema.Resource {
 				ForceNew:     true,
 				ValidateFunc: validateAwsAccountId,
 			},
+			"proposal_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"recreate_on_rejected": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"requested_allowed_prefixes": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -103,14 +123,30 @@ func resourceAwsDxGatewayAssociationProposalRead(d *schema.ResourceData, meta in
 		return fmt.Errorf("error reading Direct Connect Gateway Association Proposal (%s): %s", d.Id(), err)
 	}
 
-	if proposal == nil {
-		log.Printf("[WARN] Direct Connect Gateway Association Proposal (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
-	}
+	if proposal == nil || aws.StringValue(proposal.ProposalState) == directconnect.GatewayAssociationProposalStateDeleted {
+		// Once accepted, a proposal may be deleted; the gateway association it proposed is what remains.
+		association, err := dxGatewayAssociationProposalAssociation(conn, d.Get("dx_gateway_id").(string), d.Get("associated_gateway_id").(string))
 
-	if aws.StringValue(proposal.ProposalState) == directconnect.GatewayAssociationProposalStateDeleted {
-		log.Printf("[WARN] Direct Connect Gateway Association Proposal (%s) deleted, removing from state", d.Id())
+		if err != nil {
+			return fmt.Errorf("error reading Direct Connect Gateway Association Proposal (%s) association: %s", d.Id(), err)
+		}
+
+		if association != nil {
+			log.Printf("[INFO] Direct Connect Gateway Association Proposal (%s) accepted and associated", d.Id())
+			if err := d.Set("accepted_allowed_prefixes", flattenDirectConnectGatewayAssociationProposalAllowedPrefixes(association.AllowedPrefixesToDirectConnectGateway)); err != nil {
+				return fmt.Errorf("error setting accepted_allowed_prefixes: %s", err)
+			}
+			d.Set("proposal_state", directconnect.GatewayAssociationProposalStateAccepted)
+			return nil
+		}
+
+		if !d.Get("recreate_on_rejected").(bool) {
+			log.Printf("[WARN] Direct Connect Gateway Association Proposal (%s) rejected or deleted, not recreating", d.Id())
+			d.Set("proposal_state", directconnect.GatewayAssociationProposalStateDeleted)
+			return nil
+		}
+
+		log.Printf("[WARN] Direct Connect Gateway Association Proposal (%s) rejected or deleted, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
@@ -123,11 +159,34 @@ func resourceAwsDxGatewayAssociationProposalRead(d *schema.ResourceData, meta in
 		return fmt.Errorf("error setting allowed_prefixes: %s", err)
 	}
 
+	switch aws.StringValue(proposal.ProposalState) {
+	case directconnect.GatewayAssociationProposalStateRequested:
+		// Accepting the proposal with overridden prefixes changes the returned requested prefixes,
+		// so they are only recorded while the proposal is awaiting acceptance.
+		if err := d.Set("requested_allowed_prefixes", flattenDirectConnectGatewayAssociationProposalAllowedPrefixes(proposal.RequestedAllowedPrefixesToDirectConnectGateway)); err != nil {
+			return fmt.Errorf("error setting requested_allowed_prefixes: %s", err)
+		}
+		d.Set("accepted_allowed_prefixes", nil)
+	case directconnect.GatewayAssociationProposalStateAccepted:
+		association, err := dxGatewayAssociationProposalAssociation(conn, aws.StringValue(proposal.DirectConnectGatewayId), aws.StringValue(proposal.AssociatedGateway.Id))
+
+		if err != nil {
+			return fmt.Errorf("error reading Direct Connect Gateway Association Proposal (%s) association: %s", d.Id(), err)
+		}
+
+		if association != nil {
+			if err := d.Set("accepted_allowed_prefixes", flattenDirectConnectGatewayAssociationProposalAllowedPrefixes(association.AllowedPrefixesToDirectConnectGateway)); err != nil {
+				return fmt.Errorf("error setting accepted_allowed_prefixes: %s", err)
+			}
+		}
+	}
+
 	d.Set("associated_gateway_id", proposal.AssociatedGateway.Id)
 	d.Set("associated_gateway_owner_account_id", proposal.AssociatedGateway.OwnerAccount)
 	d.Set("associated_gateway_type", proposal.AssociatedGateway.Type)
 	d.Set("dx_gateway_id", proposal.DirectConnectGatewayId)
 	d.Set("dx_gateway_owner_account_id", proposal.DirectConnectGatewayOwnerAccount)
+	d.Set("proposal_state", proposal.ProposalState)
 
 	return nil
 }
@@ -135,6 +194,12 @@ func resourceAwsDxGatewayAssociationProposalRead(d *schema.ResourceData, meta in
 func resourceAwsDxGatewayAssociationProposalDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).dxconn
 
+	// Proposals that have been accepted or deleted can no longer be deleted.
+	if state := d.Get("proposal_state").(string); state != "" && state != directconnect.GatewayAssociationProposalStateRequested {
+		log.Printf("[DEBUG] Direct Connect Gateway Association Proposal (%s) is %s, removing from state", d.Id(), state)
+		return nil
+	}
+
 	input := &directconnect.DeleteDirectConnectGatewayAssociationProposalInput{
 		ProposalId: aws.String(d.Id()),
 	}
@@ -150,6 +215,13 @@ func resourceAwsDxGatewayAssociationProposalDelete(d *schema.ResourceData, meta
 	return nil
 }
 
+func resourceAwsDxGatewayAssociationProposalImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// Apply the argument's default, as a rejected proposal would otherwise not be recreated after import.
+	d.Set("recreate_on_rejected", true)
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func describeDirectConnectGatewayAssociationProposal(conn *directconnect.DirectConnect, proposalID string) (*directconnect.GatewayAssociationProposal, error) {
 	input := &directconnect.DescribeDirectConnectGatewayAssociationProposalsInput{
 		ProposalId: aws.String(proposalID),
@@ -182,6 +254,34 @@ func describeDirectConnectGatewayAssociationProposal(conn *directconnect.DirectC
 	return nil, nil
 }
 
+// dxGatewayAssociationProposalAssociation returns the association of the Direct Connect gateway with the associated gateway,
+// or nil if they are not associated.
+func dxGatewayAssociationProposalAssociation(conn *directconnect.DirectConnect, dxgwId, gwId string) (*directconnect.GatewayAssociation, error) {
+	if dxgwId == "" || gwId == "" {
+		return nil, nil
+	}
+
+	output, err := conn.DescribeDirectConnectGatewayAssociations(&directconnect.DescribeDirectConnectGatewayAssociationsInput{
+		AssociatedGatewayId:    aws.String(gwId),
+		DirectConnectGatewayId: aws.String(dxgwId),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, association := range output.DirectConnectGatewayAssociations {
+		switch aws.StringValue(association.AssociationState) {
+		case directconnect.GatewayAssociationStateAssociating,
+			directconnect.GatewayAssociationStateAssociated,
+			directconnect.GatewayAssociationStateUpdating:
+			return association, nil
+		}
+	}
+
+	return nil, nil
+}
+
 func expandDirectConnectGatewayAssociationProposalAllowedPrefixes(allowedPrefixes []interface{}) []*directconnect.RouteFilterPrefix {
 	if len(allowedPrefixes) == 0 {
 		return nil
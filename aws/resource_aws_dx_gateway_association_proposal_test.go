@@ -3,9 +3,11 @@ package aws
 import (
 	"fmt"
 	"log"
+	"reflect"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/directconnect"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -77,6 +79,189 @@ func testSweepDirectConnectGatewayAssociationProposals(region string) error {
 	return nil
 }
 
+func TestDxGatewayAssociationProposalRead_proposalState(t *testing.T) {
+	testCases := []struct {
+		Name               string
+		ProposalState      string
+		AssociationState   string
+		RecreateOnRejected bool
+		ExpectedId         string
+		ExpectedState      string
+	}{
+		{
+			Name:               "requested",
+			ProposalState:      directconnect.GatewayAssociationProposalStateRequested,
+			RecreateOnRejected: true,
+			ExpectedId:         "ac90e8b1-8e42-4a5b-9c2f-0123456789ab",
+			ExpectedState:      directconnect.GatewayAssociationProposalStateRequested,
+		},
+		{
+			Name:               "accepted",
+			ProposalState:      directconnect.GatewayAssociationProposalStateAccepted,
+			AssociationState:   directconnect.GatewayAssociationStateAssociated,
+			RecreateOnRejected: true,
+			ExpectedId:         "ac90e8b1-8e42-4a5b-9c2f-0123456789ab",
+			ExpectedState:      directconnect.GatewayAssociationProposalStateAccepted,
+		},
+		{
+			Name:               "accepted and deleted",
+			ProposalState:      directconnect.GatewayAssociationProposalStateDeleted,
+			AssociationState:   directconnect.GatewayAssociationStateAssociated,
+			RecreateOnRejected: true,
+			ExpectedId:         "ac90e8b1-8e42-4a5b-9c2f-0123456789ab",
+			ExpectedState:      directconnect.GatewayAssociationProposalStateAccepted,
+		},
+		{
+			Name:               "accepted and not found",
+			AssociationState:   directconnect.GatewayAssociationStateAssociated,
+			RecreateOnRejected: true,
+			ExpectedId:         "ac90e8b1-8e42-4a5b-9c2f-0123456789ab",
+			ExpectedState:      directconnect.GatewayAssociationProposalStateAccepted,
+		},
+		{
+			Name:               "rejected",
+			ProposalState:      directconnect.GatewayAssociationProposalStateDeleted,
+			RecreateOnRejected: true,
+			ExpectedId:         "",
+		},
+		{
+			Name:               "rejected and disassociated",
+			ProposalState:      directconnect.GatewayAssociationProposalStateDeleted,
+			AssociationState:   directconnect.GatewayAssociationStateDisassociated,
+			RecreateOnRejected: true,
+			ExpectedId:         "",
+		},
+		{
+			Name:               "not found",
+			RecreateOnRejected: true,
+			ExpectedId:         "",
+		},
+		{
+			Name:          "rejected without recreate",
+			ProposalState: directconnect.GatewayAssociationProposalStateDeleted,
+			ExpectedId:    "ac90e8b1-8e42-4a5b-9c2f-0123456789ab",
+			ExpectedState: directconnect.GatewayAssociationProposalStateDeleted,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			conn := testDxConnWithStub(t, func(r *request.Request) {
+				switch data := r.Data.(type) {
+				case *directconnect.DescribeDirectConnectGatewayAssociationProposalsOutput:
+					if testCase.ProposalState == "" {
+						return
+					}
+
+					data.DirectConnectGatewayAssociationProposals = []*directconnect.GatewayAssociationProposal{{
+						AssociatedGateway: &directconnect.AssociatedGateway{
+							Id:           aws.String("vgw-12345678"),
+							OwnerAccount: aws.String("123456789012"),
+							Type:         aws.String(directconnect.GatewayTypeVirtualPrivateGateway),
+						},
+						DirectConnectGatewayId:           aws.String("dxgw-12345678"),
+						DirectConnectGatewayOwnerAccount: aws.String("210987654321"),
+						ProposalId:                       aws.String("ac90e8b1-8e42-4a5b-9c2f-0123456789ab"),
+						ProposalState:                    aws.String(testCase.ProposalState),
+					}}
+				case *directconnect.DescribeDirectConnectGatewayAssociationsOutput:
+					if testCase.AssociationState == "" {
+						return
+					}
+
+					data.DirectConnectGatewayAssociations = []*directconnect.GatewayAssociation{{
+						AssociationState: aws.String(testCase.AssociationState),
+					}}
+				}
+			})
+
+			d := resourceAwsDxGatewayAssociationProposal().Data(nil)
+			d.SetId("ac90e8b1-8e42-4a5b-9c2f-0123456789ab")
+			d.Set("associated_gateway_id", "vgw-12345678")
+			d.Set("dx_gateway_id", "dxgw-12345678")
+			d.Set("recreate_on_rejected", testCase.RecreateOnRejected)
+
+			if err := resourceAwsDxGatewayAssociationProposalRead(d, &AWSClient{dxconn: conn}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got, expected := d.Id(), testCase.ExpectedId; got != expected {
+				t.Errorf("got ID %q, expected %q", got, expected)
+			}
+
+			if testCase.ExpectedId == "" {
+				return
+			}
+
+			if got, expected := d.Get("proposal_state").(string), testCase.ExpectedState; got != expected {
+				t.Errorf("got proposal_state %q, expected %q", got, expected)
+			}
+		})
+	}
+}
+
+func TestDxGatewayAssociationProposalRead_requestedAndAcceptedAllowedPrefixes(t *testing.T) {
+	proposalState := directconnect.GatewayAssociationProposalStateRequested
+	requestedPrefixes := []*directconnect.RouteFilterPrefix{{Cidr: aws.String("10.255.255.0/28")}, {Cidr: aws.String("10.255.255.16/28")}}
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeDirectConnectGatewayAssociationProposalsOutput:
+			data.DirectConnectGatewayAssociationProposals = []*directconnect.GatewayAssociationProposal{{
+				AssociatedGateway: &directconnect.AssociatedGateway{
+					Id:           aws.String("vgw-12345678"),
+					OwnerAccount: aws.String("123456789012"),
+					Type:         aws.String(directconnect.GatewayTypeVirtualPrivateGateway),
+				},
+				DirectConnectGatewayId:           aws.String("dxgw-12345678"),
+				DirectConnectGatewayOwnerAccount: aws.String("210987654321"),
+				ProposalId:                       aws.String("ac90e8b1-8e42-4a5b-9c2f-0123456789ab"),
+				ProposalState:                    aws.String(proposalState),
+				RequestedAllowedPrefixesToDirectConnectGateway: requestedPrefixes,
+			}}
+		case *directconnect.DescribeDirectConnectGatewayAssociationsOutput:
+			if proposalState != directconnect.GatewayAssociationProposalStateAccepted {
+				return
+			}
+
+			data.DirectConnectGatewayAssociations = []*directconnect.GatewayAssociation{{
+				AllowedPrefixesToDirectConnectGateway: []*directconnect.RouteFilterPrefix{{Cidr: aws.String("10.255.255.0/28")}},
+				AssociationState:                      aws.String(directconnect.GatewayAssociationStateAssociated),
+			}}
+		}
+	})
+	meta := &AWSClient{dxconn: conn}
+
+	d := resourceAwsDxGatewayAssociationProposal().Data(nil)
+	d.SetId("ac90e8b1-8e42-4a5b-9c2f-0123456789ab")
+
+	if err := resourceAwsDxGatewayAssociationProposalRead(d, meta); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, expected := d.Get("requested_allowed_prefixes").(*schema.Set).Len(), 2; got != expected {
+		t.Errorf("requested: got %d requested_allowed_prefixes, expected %d", got, expected)
+	}
+	if got, expected := d.Get("accepted_allowed_prefixes").(*schema.Set).Len(), 0; got != expected {
+		t.Errorf("requested: got %d accepted_allowed_prefixes, expected %d", got, expected)
+	}
+
+	// The owner accepts the proposal with one of the requested prefixes,
+	// which also changes the prefixes the proposal reports as requested.
+	proposalState = directconnect.GatewayAssociationProposalStateAccepted
+	requestedPrefixes = requestedPrefixes[:1]
+
+	if err := resourceAwsDxGatewayAssociationProposalRead(d, meta); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, expected := d.Get("requested_allowed_prefixes").(*schema.Set).Len(), 2; got != expected {
+		t.Errorf("accepted: got %d requested_allowed_prefixes, expected %d", got, expected)
+	}
+	if got, expected := d.Get("accepted_allowed_prefixes").(*schema.Set).List(), []interface{}{"10.255.255.0/28"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("accepted: got accepted_allowed_prefixes %v, expected %v", got, expected)
+	}
+}
+
 func TestAccAwsDxGatewayAssociationProposal_basicVpnGateway(t *testing.T) {
 	var proposal1 directconnect.GatewayAssociationProposal
 	var providers []*schema.Provider
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/directconnect"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -183,6 +184,207 @@ func testSweepDirectConnectGatewayAssociations(region string) error {
 }
 
 // V0 state upgrade testing must be done via acceptance testing due to API call
+func TestDxGatewayAssociationValidateAllowedPrefixesAddressFamily(t *testing.T) {
+	testCases := []struct {
+		addressFamily   string
+		allowedPrefixes []string
+		expectError     bool
+	}{
+		{addressFamily: "ipv4", allowedPrefixes: []string{}, expectError: false},
+		{addressFamily: "ipv4", allowedPrefixes: []string{"10.255.255.0/28", "10.255.255.16/28"}, expectError: false},
+		{addressFamily: "ipv4", allowedPrefixes: []string{"10.255.255.0/28", "2001:db8::/56"}, expectError: true},
+		{addressFamily: "ipv6", allowedPrefixes: []string{"2001:db8::/56"}, expectError: false},
+		{addressFamily: "ipv6", allowedPrefixes: []string{"10.255.255.0/28"}, expectError: true},
+		{addressFamily: "ipv6", allowedPrefixes: []string{"::ffff:10.255.255.0/120"}, expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		err := dxGatewayAssociationValidateAllowedPrefixesAddressFamily(testCase.addressFamily, aws.StringSlice(testCase.allowedPrefixes))
+
+		if testCase.expectError && err == nil {
+			t.Errorf("allowed_prefixes_address_family = %q, allowed_prefixes = %v: expected error, got none", testCase.addressFamily, testCase.allowedPrefixes)
+		}
+		if !testCase.expectError && err != nil {
+			t.Errorf("allowed_prefixes_address_family = %q, allowed_prefixes = %v: unexpected error: %s", testCase.addressFamily, testCase.allowedPrefixes, err)
+		}
+	}
+}
+
+func TestDxGatewayAssociationCheckLimits(t *testing.T) {
+	var associations []*directconnect.GatewayAssociation
+	for i := 0; i < 3; i++ {
+		associations = append(associations, &directconnect.GatewayAssociation{
+			AssociatedGateway: &directconnect.AssociatedGateway{Type: aws.String(directconnect.GatewayTypeTransitGateway)},
+			AssociationState:  aws.String(directconnect.GatewayAssociationStateAssociated),
+		})
+	}
+	for i := 0; i < 9; i++ {
+		associations = append(associations, &directconnect.GatewayAssociation{
+			AssociatedGateway: &directconnect.AssociatedGateway{Type: aws.String(directconnect.GatewayTypeVirtualPrivateGateway)},
+			AssociationState:  aws.String(directconnect.GatewayAssociationStateAssociated),
+		})
+	}
+	associations = append(associations, &directconnect.GatewayAssociation{
+		AssociatedGateway: &directconnect.AssociatedGateway{Type: aws.String(directconnect.GatewayTypeVirtualPrivateGateway)},
+		AssociationState:  aws.String(directconnect.GatewayAssociationStateDisassociating),
+	})
+
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeDirectConnectGatewayAssociationsOutput:
+			// Return the associations over two pages.
+			if aws.StringValue(r.Params.(*directconnect.DescribeDirectConnectGatewayAssociationsInput).NextToken) == "" {
+				data.DirectConnectGatewayAssociations = associations[:5]
+				data.NextToken = aws.String("page2")
+			} else {
+				data.DirectConnectGatewayAssociations = associations[5:]
+			}
+		}
+	})
+
+	testCases := []struct {
+		gwType             string
+		allowedPrefixes    int
+		expectedViolations int
+	}{
+		{gwType: directconnect.GatewayTypeVirtualPrivateGateway, allowedPrefixes: -1, expectedViolations: 0},
+		{gwType: directconnect.GatewayTypeTransitGateway, allowedPrefixes: -1, expectedViolations: 1},
+		{gwType: directconnect.GatewayTypeTransitGateway, allowedPrefixes: 21, expectedViolations: 2},
+	}
+
+	for _, testCase := range testCases {
+		violations, err := dxGatewayAssociationCheckLimits(conn, "dxgw-12345678", testCase.gwType, testCase.allowedPrefixes)
+
+		if err != nil {
+			t.Errorf("gateway type = %q, allowed prefixes = %d: unexpected error: %s", testCase.gwType, testCase.allowedPrefixes, err)
+			continue
+		}
+		if len(violations) != testCase.expectedViolations {
+			t.Errorf("gateway type = %q, allowed prefixes = %d: expected %d violations, got %v", testCase.gwType, testCase.allowedPrefixes, testCase.expectedViolations, violations)
+		}
+	}
+}
+
+func TestDxGatewayAssociationGatewayTypeOf(t *testing.T) {
+	testCases := map[string]string{
+		"tgw-0123456789abcdef0": directconnect.GatewayTypeTransitGateway,
+		"vgw-01234567":          directconnect.GatewayTypeVirtualPrivateGateway,
+		"igw-01234567":          "",
+	}
+
+	for gwId, expected := range testCases {
+		if got := dxGatewayAssociationGatewayTypeOf(gwId); got != expected {
+			t.Errorf("%s: expected %q, got %q", gwId, expected, got)
+		}
+	}
+}
+
+func TestDxGatewayAssociationRead_allowedPrefixesDrift(t *testing.T) {
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeDirectConnectGatewayAssociationsOutput:
+			data.DirectConnectGatewayAssociations = []*directconnect.GatewayAssociation{{
+				AllowedPrefixesToDirectConnectGateway: []*directconnect.RouteFilterPrefix{
+					{Cidr: aws.String("10.255.255.0/28")},
+					{Cidr: aws.String("10.255.255.16/28")},
+					// Added out-of-band.
+					{Cidr: aws.String("10.255.255.32/28")},
+				},
+				AssociatedGateway: &directconnect.AssociatedGateway{
+					Id:           aws.String("vgw-12345678"),
+					OwnerAccount: aws.String("123456789012"),
+					Type:         aws.String(directconnect.GatewayTypeVirtualPrivateGateway),
+				},
+				AssociationId:                    aws.String("12345678-1234-1234-1234-123456789012"),
+				AssociationState:                 aws.String(directconnect.GatewayAssociationStateAssociated),
+				DirectConnectGatewayId:           aws.String("dxgw-12345678"),
+				DirectConnectGatewayOwnerAccount: aws.String("123456789012"),
+			}}
+		}
+	})
+	meta := &AWSClient{dxconn: conn}
+
+	r := resourceAwsDxGatewayAssociation()
+	d := r.Data(nil)
+	d.SetId(dxGatewayAssociationId("dxgw-12345678", "vgw-12345678"))
+	d.Set("dx_gateway_association_id", "12345678-1234-1234-1234-123456789012")
+	d.Set("allowed_prefixes", []interface{}{"10.255.255.0/28", "10.255.255.16/28"})
+
+	if err := resourceAwsDxGatewayAssociationRead(d, meta); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, expected := d.Get("allowed_prefixes").(*schema.Set).Len(), 3; got != expected {
+		t.Fatalf("expected %d allowed prefixes after read, got %d", expected, got)
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"allowed_prefixes":      []interface{}{"10.255.255.0/28", "10.255.255.16/28"},
+		"associated_gateway_id": "vgw-12345678",
+		"dx_gateway_id":         "dxgw-12345678",
+	})
+
+	diff, err := r.Diff(context.Background(), d.State(), config, meta)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff == nil {
+		t.Fatal("expected a diff removing the out-of-band allowed prefix, got none")
+	}
+	if diff.RequiresNew() {
+		t.Error("expected an in-place update, got a diff requiring replacement")
+	}
+
+	if attr, ok := diff.Attributes["allowed_prefixes.#"]; !ok || attr.Old != "3" || attr.New != "2" {
+		t.Errorf("expected allowed_prefixes.# to change from 3 to 2, got %#v", attr)
+	}
+	if attr, ok := diff.Attributes[fmt.Sprintf("allowed_prefixes.%d", schema.HashString("10.255.255.32/28"))]; !ok || !attr.NewRemoved {
+		t.Errorf("expected out-of-band allowed prefix to be removed, got %#v", attr)
+	}
+}
+
+func TestDxGatewayAssociationUpdateStateConf(t *testing.T) {
+	states := []string{
+		// Not yet transitioned to updating.
+		directconnect.GatewayAssociationStateAssociated,
+		directconnect.GatewayAssociationStateUpdating,
+		directconnect.GatewayAssociationStateUpdating,
+		directconnect.GatewayAssociationStateAssociated,
+	}
+	var describeCalls int
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeDirectConnectGatewayAssociationsOutput:
+			state := states[len(states)-1]
+			if describeCalls < len(states) {
+				state = states[describeCalls]
+			}
+			describeCalls++
+
+			data.DirectConnectGatewayAssociations = []*directconnect.GatewayAssociation{{
+				AssociatedGateway:      &directconnect.AssociatedGateway{Id: aws.String("vgw-12345678")},
+				AssociationId:          aws.String("12345678-1234-1234-1234-123456789012"),
+				AssociationState:       aws.String(state),
+				DirectConnectGatewayId: aws.String("dxgw-12345678"),
+			}}
+		}
+	})
+
+	stateConf := dxGatewayAssociationUpdateStateConf(conn, "12345678-1234-1234-1234-123456789012", 1*time.Minute)
+	stateConf.Delay = 0
+	stateConf.MinTimeout = 0
+	stateConf.PollInterval = 10 * time.Millisecond
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The initial associated state must not end the wait.
+	if expected := len(states) + 1; describeCalls != expected {
+		t.Errorf("expected %d DescribeDirectConnectGatewayAssociations calls, got %d", expected, describeCalls)
+	}
+}
+
 func TestAccAwsDxGatewayAssociation_V0StateUpgrade(t *testing.T) {
 	resourceName := "aws_dx_gateway_association.test"
 	rName := acctest.RandomWithPrefix("tf-acc-test")
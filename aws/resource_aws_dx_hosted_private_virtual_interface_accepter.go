@@ -6,8 +6,8 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
 )
@@ -27,11 +27,60 @@ func resourceAwsDxHostedPrivateVirtualInterfaceAccepter() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"aws_device": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"aws_logical_device_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bgp_auth_key_set": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"bgp_peer_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"cloudwatch_dimensions": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			"dx_gateway_id": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				ForceNew:      true,
-				ConflictsWith: []string{"vpn_gateway_id"},
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"dx_gateway_id", "vpn_gateway_id"},
+			},
+			"ignore_tag_keys": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"managed_tag_keys": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"partition": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"required_tag_keys": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
 			},
 			"tags":     tagsSchema(),
 			"tags_all": tagsSchemaComputed(),
@@ -40,11 +89,15 @@ func resourceAwsDxHostedPrivateVirtualInterfaceAccepter() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"virtual_interface_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"vpn_gateway_id": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				ForceNew:      true,
-				ConflictsWith: []string{"dx_gateway_id"},
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"dx_gateway_id", "vpn_gateway_id"},
 			},
 		},
 
@@ -53,45 +106,40 @@ func resourceAwsDxHostedPrivateVirtualInterfaceAccepter() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
-		CustomizeDiff: SetTagsDiff,
+		CustomizeDiff: customdiff.Sequence(
+			SetTagsDiff,
+			dxVirtualInterfaceIgnoreTagKeysCustomizeDiff,
+			dxVirtualInterfaceRequiredTagsCustomizeDiff,
+			dxHostedVirtualInterfaceAccepterManagedTagKeysCustomizeDiff,
+		),
 	}
 }
 
 func resourceAwsDxHostedPrivateVirtualInterfaceAccepterCreate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).dxconn
 
-	vgwIdRaw, vgwOk := d.GetOk("vpn_gateway_id")
-	dxgwIdRaw, dxgwOk := d.GetOk("dx_gateway_id")
-	if vgwOk == dxgwOk {
-		return fmt.Errorf(
-			"One of ['vpn_gateway_id', 'dx_gateway_id'] must be set to create a Direct Connect private virtual interface accepter")
-	}
-
 	vifId := d.Get("virtual_interface_id").(string)
 	req := &directconnect.ConfirmPrivateVirtualInterfaceInput{
 		VirtualInterfaceId: aws.String(vifId),
 	}
-	if dxgwOk && dxgwIdRaw.(string) != "" {
-		req.DirectConnectGatewayId = aws.String(dxgwIdRaw.(string))
+	if v, ok := d.GetOk("dx_gateway_id"); ok {
+		req.DirectConnectGatewayId = aws.String(v.(string))
 	}
-	if vgwOk && vgwIdRaw.(string) != "" {
-		req.VirtualGatewayId = aws.String(vgwIdRaw.(string))
+	if v, ok := d.GetOk("vpn_gateway_id"); ok {
+		req.VirtualGatewayId = aws.String(v.(string))
 	}
 
 	log.Printf("[DEBUG] Accepting Direct Connect hosted private virtual interface: %s", req)
-	_, err := conn.ConfirmPrivateVirtualInterface(req)
+	err := dxVirtualInterfaceConfirmWhenConfirmable(conn, vifId, d.Timeout(schema.TimeoutCreate), func() error {
+		_, err := conn.ConfirmPrivateVirtualInterface(req)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("error accepting Direct Connect hosted private virtual interface: %s", err)
 	}
 
 	d.SetId(vifId)
-	arn := arn.ARN{
-		Partition: meta.(*AWSClient).partition,
-		Region:    meta.(*AWSClient).region,
-		Service:   "directconnect",
-		AccountID: meta.(*AWSClient).accountid,
-		Resource:  fmt.Sprintf("dxvif/%s", d.Id()),
-	}.String()
+	arn := dxVirtualInterfaceArn(meta.(*AWSClient), meta.(*AWSClient).accountid, d.Id())
 	d.Set("arn", arn)
 
 	if err := dxHostedPrivateVirtualInterfaceAccepterWaitUntilAvailable(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
@@ -123,9 +171,22 @@ func resourceAwsDxHostedPrivateVirtualInterfaceAccepterRead(d *schema.ResourceDa
 		return nil
 	}
 
+	d.Set("aws_device", vif.AwsDeviceV2)
+	d.Set("aws_logical_device_id", vif.AwsLogicalDeviceId)
+	if err := d.Set("cloudwatch_dimensions", flattenDxVirtualInterfaceCloudWatchDimensions(vif)); err != nil {
+		return fmt.Errorf("error setting cloudwatch_dimensions: %w", err)
+	}
 	d.Set("dx_gateway_id", vif.DirectConnectGatewayId)
+	d.Set("state", aws.StringValue(vif.VirtualInterfaceState))
+	d.Set("role", dxVirtualInterfaceRole(vif, meta.(*AWSClient).accountid, true))
+	d.Set("bgp_auth_key_set", aws.StringValue(vif.AuthKey) != "")
+	if err := d.Set("bgp_peer_ids", flattenDxVirtualInterfaceBgpPeerIds(vif)); err != nil {
+		return fmt.Errorf("error setting bgp_peer_ids: %w", err)
+	}
+	d.Set("virtual_interface_type", vif.VirtualInterfaceType)
 	d.Set("virtual_interface_id", vif.VirtualInterfaceId)
 	d.Set("vpn_gateway_id", vif.VirtualGatewayId)
+	d.Set("partition", meta.(*AWSClient).partition)
 
 	arn := d.Get("arn").(string)
 	tags, err := keyvaluetags.DirectconnectListTags(conn, arn)
@@ -134,7 +195,8 @@ func resourceAwsDxHostedPrivateVirtualInterfaceAccepterRead(d *schema.ResourceDa
 		return fmt.Errorf("error listing tags for Direct Connect hosted private virtual interface (%s): %s", arn, err)
 	}
 
-	tags = tags.IgnoreAws().IgnoreConfig(ignoreTagsConfig)
+	tags = dxFilterSystemTags(tags, ignoreTagsConfig).Ignore(keyvaluetags.New(d.Get("ignore_tag_keys").(*schema.Set).List()))
+	tags = dxHostedVirtualInterfaceAccepterManagedTags(d, tags)
 
 	//lintignore:AWSR002
 	if err := d.Set("tags", tags.RemoveDefaultConfig(defaultTagsConfig).Map()); err != nil {
@@ -176,15 +238,19 @@ func resourceAwsDxHostedPrivateVirtualInterfaceAccepterImport(d *schema.Resource
 		return nil, fmt.Errorf("virtual interface (%s) has incorrect type: %s", d.Id(), vifType)
 	}
 
-	arn := arn.ARN{
-		Partition: meta.(*AWSClient).partition,
-		Region:    meta.(*AWSClient).region,
-		Service:   "directconnect",
-		AccountID: meta.(*AWSClient).accountid,
-		Resource:  fmt.Sprintf("dxvif/%s", d.Id()),
-	}.String()
+	arn := dxVirtualInterfaceArn(meta.(*AWSClient), meta.(*AWSClient).accountid, d.Id())
 	d.Set("arn", arn)
 
+	tags, err := keyvaluetags.DirectconnectListTags(conn, arn)
+
+	if err != nil {
+		return nil, fmt.Errorf("error listing tags for Direct Connect hosted private virtual interface (%s): %s", arn, err)
+	}
+
+	if err := d.Set("managed_tag_keys", dxFilterSystemTags(tags, meta.(*AWSClient).IgnoreTagsConfig).Keys()); err != nil {
+		return nil, fmt.Errorf("error setting managed_tag_keys: %w", err)
+	}
+
 	return []*schema.ResourceData{d}, nil
 }
 
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/directconnect"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -15,6 +16,40 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
+func TestDxHostedPrivateVirtualInterfaceAccepterRead_managedTagKeys(t *testing.T) {
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+			}}
+		case *directconnect.DescribeTagsOutput:
+			// Tags applied by both the creator and the accepter accounts.
+			data.ResourceTags = []*directconnect.ResourceTag{{
+				Tags: []*directconnect.Tag{
+					{Key: aws.String("CreatorKey"), Value: aws.String("CreatorValue")},
+					{Key: aws.String("AccepterKey"), Value: aws.String("AccepterValue")},
+				},
+			}}
+		}
+	})
+
+	d := resourceAwsDxHostedPrivateVirtualInterfaceAccepter().Data(nil)
+	d.SetId("dxvif-12345678")
+	d.Set("arn", "arn:aws:directconnect:us-west-2:123456789012:dxvif/dxvif-12345678")
+	d.Set("managed_tag_keys", []string{"AccepterKey"})
+
+	if err := resourceAwsDxHostedPrivateVirtualInterfaceAccepterRead(d, &AWSClient{dxconn: conn}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tags := d.Get("tags").(map[string]interface{})
+	if len(tags) != 1 || tags["AccepterKey"] != "AccepterValue" {
+		t.Errorf("expected only the accepter's tag, got %v", tags)
+	}
+}
+
 func TestAccAwsDxHostedPrivateVirtualInterface_basic(t *testing.T) {
 	key := "DX_CONNECTION_ID"
 	connectionId := os.Getenv(key)
@@ -65,10 +100,11 @@ func TestAccAwsDxHostedPrivateVirtualInterface_basic(t *testing.T) {
 			},
 			// Test import.
 			{
-				Config:            testAccDxHostedPrivateVirtualInterfaceConfig_basic(connectionId, rName, bgpAsn, vlan),
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				Config:                  testAccDxHostedPrivateVirtualInterfaceConfig_basic(connectionId, rName, bgpAsn, vlan),
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"created_at"},
 			},
 		},
 	})
@@ -155,6 +191,48 @@ func TestAccAwsDxHostedPrivateVirtualInterface_AccepterTags(t *testing.T) {
 	})
 }
 
+func TestAccAwsDxHostedPrivateVirtualInterface_AutoAccept(t *testing.T) {
+	key := "DX_CONNECTION_ID"
+	connectionId := os.Getenv(key)
+	if connectionId == "" {
+		t.Skipf("Environment variable %s is not set", key)
+	}
+
+	var vif directconnect.VirtualInterface
+	resourceName := "aws_dx_hosted_private_virtual_interface.test"
+	vpnGatewayResourceName := "aws_vpn_gateway.test"
+	rName := fmt.Sprintf("tf-testacc-private-vif-%s", acctest.RandString(9))
+	bgpAsn := acctest.RandIntRange(64512, 65534)
+	vlan := acctest.RandIntRange(2049, 4094)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		ErrorCheck:   testAccErrorCheck(t, directconnect.EndpointsID),
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDxHostedPrivateVirtualInterfaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDxHostedPrivateVirtualInterfaceConfig_autoAccept(connectionId, rName, bgpAsn, vlan),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDxHostedPrivateVirtualInterfaceExists(resourceName, &vif),
+					resource.TestCheckResourceAttr(resourceName, "auto_accept", "true"),
+					resource.TestCheckResourceAttr(resourceName, "connection_id", connectionId),
+					resource.TestCheckResourceAttr(resourceName, "dx_gateway_id", ""),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					testAccCheckResourceAttrAccountID(resourceName, "owner_account_id"),
+					resource.TestCheckResourceAttrPair(resourceName, "vpn_gateway_id", vpnGatewayResourceName, "id"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"auto_accept", "created_at"},
+			},
+		},
+	})
+}
+
 func testAccCheckAwsDxHostedPrivateVirtualInterfaceDestroy(s *terraform.State) error {
 	return testAccCheckDxVirtualInterfaceDestroy(s, "aws_dx_hosted_private_virtual_interface")
 }
@@ -238,3 +316,26 @@ resource "aws_dx_hosted_private_virtual_interface_accepter" "test" {
 }
 `, rName)
 }
+
+func testAccDxHostedPrivateVirtualInterfaceConfig_autoAccept(cid, rName string, bgpAsn, vlan int) string {
+	return fmt.Sprintf(`
+data "aws_caller_identity" "current" {}
+
+resource "aws_vpn_gateway" "test" {
+  tags = {
+    Name = %[2]q
+  }
+}
+
+resource "aws_dx_hosted_private_virtual_interface" "test" {
+  address_family   = "ipv4"
+  auto_accept      = true
+  bgp_asn          = %[3]d
+  connection_id    = %[1]q
+  name             = %[2]q
+  owner_account_id = data.aws_caller_identity.current.account_id
+  vlan             = %[4]d
+  vpn_gateway_id   = aws_vpn_gateway.test.id
+}
+`, cid, rName, bgpAsn, vlan)
+}
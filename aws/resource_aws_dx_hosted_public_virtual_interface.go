@@ -8,21 +8,29 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/naming"
 )
 
 func resourceAwsDxHostedPublicVirtualInterface() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceAwsDxHostedPublicVirtualInterfaceCreate,
 		Read:   resourceAwsDxHostedPublicVirtualInterfaceRead,
+		Update: resourceAwsDxHostedPublicVirtualInterfaceUpdate,
 		Delete: resourceAwsDxHostedPublicVirtualInterfaceDelete,
 		Importer: &schema.ResourceImporter{
 			State: resourceAwsDxHostedPublicVirtualInterfaceImport,
 		},
-		CustomizeDiff: resourceAwsDxHostedPublicVirtualInterfaceCustomizeDiff,
+		CustomizeDiff: customdiff.Sequence(
+			resourceAwsDxHostedPublicVirtualInterfaceCustomizeDiff,
+			dxHostedVirtualInterfaceAutoAcceptCustomizeDiff,
+			dxVirtualInterfaceCustomizeDiff,
+			dxVirtualInterfaceRouteFilterPrefixesCustomizeDiff,
+			dxVirtualInterfaceVlanCustomizeDiff,
+		),
 
 		Schema: map[string]*schema.Schema{
 			"address_family": {
@@ -48,36 +56,121 @@ func resourceAwsDxHostedPublicVirtualInterface() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"auto_accept": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
 			"aws_device": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"aws_logical_device_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"bgp_asn": {
-				Type:     schema.TypeInt,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDxBgpAsn,
 			},
 			"bgp_auth_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Computed:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
+			"bgp_auth_key_charset": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validateDxBgpAuthKeyCharset,
+				ConflictsWith: []string{"bgp_auth_key"},
+				RequiredWith:  []string{"bgp_auth_key_length"},
+			},
+			"bgp_auth_key_length": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validation.IntBetween(dxBgpAuthKeyMinLength, dxBgpAuthKeyMaxLength),
+				ConflictsWith: []string{"bgp_auth_key"},
+			},
+			"bgp_auth_key_set": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"bgp_peer_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"cloudwatch_dimensions": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"connection_encryption_status": {
 				Type:     schema.TypeString,
-				Optional: true,
 				Computed: true,
-				ForceNew: true,
 			},
 			"connection_id": {
 				Type:     schema.TypeString,
 				Required: true,
 				ForceNew: true,
 			},
+			"connection_owner_account_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"connection_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"customer_address": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
 				ForceNew: true,
 			},
-			"name": {
+			"drain_before_delete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"location": {
 				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Computed: true,
+			},
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"name_prefix"},
+			},
+			"name_prefix": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"name"},
+				ValidateFunc:  validateDxVirtualInterfaceNamePrefix,
+			},
+			"ordered_route_filter_prefixes": {
+				Type:             schema.TypeList,
+				Optional:         true,
+				ForceNew:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				MinItems:         1,
+				ExactlyOneOf:     []string{"ordered_route_filter_prefixes", "route_filter_prefixes"},
+				DiffSuppressFunc: dxVirtualInterfaceSuppressRouteFilterPrefixesOrderDiff,
 			},
 			"owner_account_id": {
 				Type:         schema.TypeString,
@@ -85,12 +178,54 @@ func resourceAwsDxHostedPublicVirtualInterface() *schema.Resource {
 				ForceNew:     true,
 				ValidateFunc: validateAwsAccountId,
 			},
+			"partition": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"peer_addresses_consistent": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"prevent_active_delete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"route_filter_prefixes": {
-				Type:     schema.TypeSet,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				MinItems:     1,
+				ExactlyOneOf: []string{"ordered_route_filter_prefixes", "route_filter_prefixes"},
+			},
+			"skip_delete_wait": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"sorted_route_filter_prefixes": {
+				Type:     schema.TypeList,
+				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
-				MinItems: 1,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"strict_address_family": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"virtual_interface_type": {
+				Type:     schema.TypeString,
+				Computed: true,
 			},
 			"vlan": {
 				Type:         schema.TypeInt,
@@ -98,6 +233,16 @@ func resourceAwsDxHostedPublicVirtualInterface() *schema.Resource {
 				ForceNew:     true,
 				ValidateFunc: validation.IntBetween(1, 4094),
 			},
+			"wait_for_connection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"warn_if_last_on_connection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -115,7 +260,7 @@ func resourceAwsDxHostedPublicVirtualInterfaceCreate(d *schema.ResourceData, met
 		NewPublicVirtualInterfaceAllocation: &directconnect.NewPublicVirtualInterfaceAllocation{
 			AddressFamily:        aws.String(d.Get("address_family").(string)),
 			Asn:                  aws.Int64(int64(d.Get("bgp_asn").(int))),
-			VirtualInterfaceName: aws.String(d.Get("name").(string)),
+			VirtualInterfaceName: aws.String(naming.Generate(d.Get("name").(string), d.Get("name_prefix").(string))),
 			Vlan:                 aws.Int64(int64(d.Get("vlan").(int))),
 		},
 		OwnerAccount: aws.String(d.Get("owner_account_id").(string)),
@@ -123,8 +268,12 @@ func resourceAwsDxHostedPublicVirtualInterfaceCreate(d *schema.ResourceData, met
 	if v, ok := d.GetOk("amazon_address"); ok {
 		req.NewPublicVirtualInterfaceAllocation.AmazonAddress = aws.String(v.(string))
 	}
-	if v, ok := d.GetOk("bgp_auth_key"); ok {
-		req.NewPublicVirtualInterfaceAllocation.AuthKey = aws.String(v.(string))
+	authKey, err := dxVirtualInterfaceBgpAuthKey(d)
+	if err != nil {
+		return err
+	}
+	if authKey != "" {
+		req.NewPublicVirtualInterfaceAllocation.AuthKey = aws.String(authKey)
 	}
 	if v, ok := d.GetOk("customer_address"); ok {
 		req.NewPublicVirtualInterfaceAllocation.CustomerAddress = aws.String(v.(string))
@@ -132,6 +281,19 @@ func resourceAwsDxHostedPublicVirtualInterfaceCreate(d *schema.ResourceData, met
 	if v, ok := d.GetOk("route_filter_prefixes"); ok {
 		req.NewPublicVirtualInterfaceAllocation.RouteFilterPrefixes = expandDxRouteFilterPrefixes(v.(*schema.Set))
 	}
+	if v, ok := d.GetOk("ordered_route_filter_prefixes"); ok {
+		req.NewPublicVirtualInterfaceAllocation.RouteFilterPrefixes = expandDxRouteFilterPrefixes(schema.NewSet(schema.HashString, v.([]interface{})))
+	}
+
+	if d.Get("wait_for_connection").(bool) {
+		if err := dxVirtualInterfaceWaitUntilConnectionAvailable(conn, d.Get("connection_id").(string), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
+	if err := dxVirtualInterfaceCheckVlanAvailable(conn, d.Get("connection_id").(string), d.Get("vlan").(int), ""); err != nil {
+		return err
+	}
 
 	log.Printf("[DEBUG] Allocating Direct Connect hosted public virtual interface: %s", req)
 	resp, err := conn.AllocatePublicVirtualInterface(req)
@@ -140,11 +302,31 @@ func resourceAwsDxHostedPublicVirtualInterfaceCreate(d *schema.ResourceData, met
 	}
 
 	d.SetId(aws.StringValue(resp.VirtualInterfaceId))
+	d.Set("created_at", time.Now().UTC().Format(time.RFC3339))
 
 	if err := dxHostedPublicVirtualInterfaceWaitUntilAvailable(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
 		return err
 	}
 
+	if d.Get("auto_accept").(bool) {
+		confirmReq := &directconnect.ConfirmPublicVirtualInterfaceInput{
+			VirtualInterfaceId: aws.String(d.Id()),
+		}
+
+		log.Printf("[DEBUG] Accepting Direct Connect hosted public virtual interface: %s", confirmReq)
+		err := dxVirtualInterfaceConfirmWhenConfirmable(conn, d.Id(), d.Timeout(schema.TimeoutCreate), func() error {
+			_, err := conn.ConfirmPublicVirtualInterface(confirmReq)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("error accepting Direct Connect hosted public virtual interface (%s): %s", d.Id(), err)
+		}
+
+		if err := dxHostedPublicVirtualInterfaceAccepterWaitUntilAvailable(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
 	return resourceAwsDxHostedPublicVirtualInterfaceRead(d, meta)
 }
 
@@ -161,32 +343,59 @@ func resourceAwsDxHostedPublicVirtualInterfaceRead(d *schema.ResourceData, meta
 		return nil
 	}
 
+	connection := dxVirtualInterfaceConnection(conn, vif)
+
 	d.Set("address_family", vif.AddressFamily)
 	d.Set("amazon_address", vif.AmazonAddress)
 	d.Set("amazon_side_asn", strconv.FormatInt(aws.Int64Value(vif.AmazonSideAsn), 10))
-	arn := arn.ARN{
-		Partition: meta.(*AWSClient).partition,
-		Region:    meta.(*AWSClient).region,
-		Service:   "directconnect",
-		AccountID: meta.(*AWSClient).accountid,
-		Resource:  fmt.Sprintf("dxvif/%s", d.Id()),
-	}.String()
+	arn := dxVirtualInterfaceArn(meta.(*AWSClient), meta.(*AWSClient).accountid, d.Id())
 	d.Set("arn", arn)
+	d.Set("partition", meta.(*AWSClient).partition)
 	d.Set("aws_device", vif.AwsDeviceV2)
+	d.Set("aws_logical_device_id", vif.AwsLogicalDeviceId)
 	d.Set("bgp_asn", vif.Asn)
 	d.Set("bgp_auth_key", vif.AuthKey)
+	d.Set("bgp_auth_key_set", aws.StringValue(vif.AuthKey) != "")
+	if err := d.Set("bgp_peer_ids", flattenDxVirtualInterfaceBgpPeerIds(vif)); err != nil {
+		return fmt.Errorf("error setting bgp_peer_ids: %w", err)
+	}
+	if err := d.Set("cloudwatch_dimensions", flattenDxVirtualInterfaceCloudWatchDimensions(vif)); err != nil {
+		return fmt.Errorf("error setting cloudwatch_dimensions: %w", err)
+	}
+	d.Set("connection_encryption_status", connection.PortEncryptionStatus)
 	d.Set("connection_id", vif.ConnectionId)
+	d.Set("connection_owner_account_id", connection.OwnerAccount)
+	d.Set("connection_state", connection.ConnectionState)
+	d.Set("location", connection.Location)
 	d.Set("customer_address", vif.CustomerAddress)
+	d.Set("peer_addresses_consistent", dxPeerAddressesConsistent(aws.StringValue(vif.AmazonAddress), aws.StringValue(vif.CustomerAddress)))
 	d.Set("name", vif.VirtualInterfaceName)
+	d.Set("name_prefix", naming.NamePrefixFromName(aws.StringValue(vif.VirtualInterfaceName)))
 	d.Set("owner_account_id", vif.OwnerAccount)
-	if err := d.Set("route_filter_prefixes", flattenDxRouteFilterPrefixes(vif.RouteFilterPrefixes)); err != nil {
+	routeFilterPrefixes := flattenDxRouteFilterPrefixes(vif.RouteFilterPrefixes)
+	if err := d.Set("route_filter_prefixes", routeFilterPrefixes); err != nil {
 		return fmt.Errorf("error setting route_filter_prefixes: %s", err)
 	}
+	if v := d.Get("ordered_route_filter_prefixes").([]interface{}); len(v) > 0 {
+		if err := d.Set("ordered_route_filter_prefixes", dxOrderedRouteFilterPrefixes(v, routeFilterPrefixes)); err != nil {
+			return fmt.Errorf("error setting ordered_route_filter_prefixes: %w", err)
+		}
+	}
+	if err := d.Set("sorted_route_filter_prefixes", dxSortCidrs(aws.StringValueSlice(expandStringSet(routeFilterPrefixes)))); err != nil {
+		return fmt.Errorf("error setting sorted_route_filter_prefixes: %w", err)
+	}
+	d.Set("state", aws.StringValue(vif.VirtualInterfaceState))
+	d.Set("role", dxVirtualInterfaceRole(vif, meta.(*AWSClient).accountid, false))
+	d.Set("virtual_interface_type", vif.VirtualInterfaceType)
 	d.Set("vlan", vif.Vlan)
 
 	return nil
 }
 
+func resourceAwsDxHostedPublicVirtualInterfaceUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceAwsDxHostedPublicVirtualInterfaceRead(d, meta)
+}
+
 func resourceAwsDxHostedPublicVirtualInterfaceDelete(d *schema.ResourceData, meta interface{}) error {
 	return dxVirtualInterfaceDelete(d, meta)
 }
@@ -206,6 +415,14 @@ func resourceAwsDxHostedPublicVirtualInterfaceImport(d *schema.ResourceData, met
 		return nil, fmt.Errorf("virtual interface (%s) has incorrect type: %s", d.Id(), vifType)
 	}
 
+	d.Set("auto_accept", false)
+	d.Set("drain_before_delete", false)
+	d.Set("prevent_active_delete", false)
+	d.Set("skip_delete_wait", false)
+	d.Set("strict_address_family", false)
+	d.Set("wait_for_connection", false)
+	d.Set("warn_if_last_on_connection", false)
+
 	return []*schema.ResourceData{d}, nil
 }
 
@@ -6,8 +6,8 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
 )
@@ -27,6 +27,55 @@ func resourceAwsDxHostedPublicVirtualInterfaceAccepter() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"aws_device": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"aws_logical_device_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bgp_auth_key_set": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"bgp_peer_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"cloudwatch_dimensions": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"ignore_tag_keys": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"managed_tag_keys": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"partition": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"required_tag_keys": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"tags":     tagsSchema(),
 			"tags_all": tagsSchemaComputed(),
 			"virtual_interface_id": {
@@ -34,6 +83,10 @@ func resourceAwsDxHostedPublicVirtualInterfaceAccepter() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"virtual_interface_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -41,7 +94,12 @@ func resourceAwsDxHostedPublicVirtualInterfaceAccepter() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
-		CustomizeDiff: SetTagsDiff,
+		CustomizeDiff: customdiff.Sequence(
+			SetTagsDiff,
+			dxVirtualInterfaceIgnoreTagKeysCustomizeDiff,
+			dxVirtualInterfaceRequiredTagsCustomizeDiff,
+			dxHostedVirtualInterfaceAccepterManagedTagKeysCustomizeDiff,
+		),
 	}
 }
 
@@ -54,19 +112,16 @@ func resourceAwsDxHostedPublicVirtualInterfaceAccepterCreate(d *schema.ResourceD
 	}
 
 	log.Printf("[DEBUG] Accepting Direct Connect hosted public virtual interface: %s", req)
-	_, err := conn.ConfirmPublicVirtualInterface(req)
+	err := dxVirtualInterfaceConfirmWhenConfirmable(conn, vifId, d.Timeout(schema.TimeoutCreate), func() error {
+		_, err := conn.ConfirmPublicVirtualInterface(req)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("error accepting Direct Connect hosted public virtual interface: %s", err)
 	}
 
 	d.SetId(vifId)
-	arn := arn.ARN{
-		Partition: meta.(*AWSClient).partition,
-		Region:    meta.(*AWSClient).region,
-		Service:   "directconnect",
-		AccountID: meta.(*AWSClient).accountid,
-		Resource:  fmt.Sprintf("dxvif/%s", d.Id()),
-	}.String()
+	arn := dxVirtualInterfaceArn(meta.(*AWSClient), meta.(*AWSClient).accountid, d.Id())
 	d.Set("arn", arn)
 
 	if err := dxHostedPublicVirtualInterfaceAccepterWaitUntilAvailable(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
@@ -99,7 +154,20 @@ func resourceAwsDxHostedPublicVirtualInterfaceAccepterRead(d *schema.ResourceDat
 		return nil
 	}
 
+	d.Set("aws_device", vif.AwsDeviceV2)
+	d.Set("aws_logical_device_id", vif.AwsLogicalDeviceId)
+	if err := d.Set("cloudwatch_dimensions", flattenDxVirtualInterfaceCloudWatchDimensions(vif)); err != nil {
+		return fmt.Errorf("error setting cloudwatch_dimensions: %w", err)
+	}
+	d.Set("state", aws.StringValue(vif.VirtualInterfaceState))
+	d.Set("role", dxVirtualInterfaceRole(vif, meta.(*AWSClient).accountid, true))
+	d.Set("bgp_auth_key_set", aws.StringValue(vif.AuthKey) != "")
+	if err := d.Set("bgp_peer_ids", flattenDxVirtualInterfaceBgpPeerIds(vif)); err != nil {
+		return fmt.Errorf("error setting bgp_peer_ids: %w", err)
+	}
+	d.Set("virtual_interface_type", vif.VirtualInterfaceType)
 	d.Set("virtual_interface_id", vif.VirtualInterfaceId)
+	d.Set("partition", meta.(*AWSClient).partition)
 
 	arn := d.Get("arn").(string)
 	tags, err := keyvaluetags.DirectconnectListTags(conn, arn)
@@ -108,7 +176,8 @@ func resourceAwsDxHostedPublicVirtualInterfaceAccepterRead(d *schema.ResourceDat
 		return fmt.Errorf("error listing tags for Direct Connect hosted public virtual interface (%s): %s", arn, err)
 	}
 
-	tags = tags.IgnoreAws().IgnoreConfig(ignoreTagsConfig)
+	tags = dxFilterSystemTags(tags, ignoreTagsConfig).Ignore(keyvaluetags.New(d.Get("ignore_tag_keys").(*schema.Set).List()))
+	tags = dxHostedVirtualInterfaceAccepterManagedTags(d, tags)
 
 	//lintignore:AWSR002
 	if err := d.Set("tags", tags.RemoveDefaultConfig(defaultTagsConfig).Map()); err != nil {
@@ -150,15 +219,19 @@ func resourceAwsDxHostedPublicVirtualInterfaceAccepterImport(d *schema.ResourceD
 		return nil, fmt.Errorf("virtual interface (%s) has incorrect type: %s", d.Id(), vifType)
 	}
 
-	arn := arn.ARN{
-		Partition: meta.(*AWSClient).partition,
-		Region:    meta.(*AWSClient).region,
-		Service:   "directconnect",
-		AccountID: meta.(*AWSClient).accountid,
-		Resource:  fmt.Sprintf("dxvif/%s", d.Id()),
-	}.String()
+	arn := dxVirtualInterfaceArn(meta.(*AWSClient), meta.(*AWSClient).accountid, d.Id())
 	d.Set("arn", arn)
 
+	tags, err := keyvaluetags.DirectconnectListTags(conn, arn)
+
+	if err != nil {
+		return nil, fmt.Errorf("error listing tags for Direct Connect hosted public virtual interface (%s): %s", arn, err)
+	}
+
+	if err := d.Set("managed_tag_keys", dxFilterSystemTags(tags, meta.(*AWSClient).IgnoreTagsConfig).Keys()); err != nil {
+		return nil, fmt.Errorf("error setting managed_tag_keys: %w", err)
+	}
+
 	return []*schema.ResourceData{d}, nil
 }
 
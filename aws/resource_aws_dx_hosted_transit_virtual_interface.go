@@ -1,22 +1,25 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/naming"
 )
 
 func resourceAwsDxHostedTransitVirtualInterface() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceAwsDxHostedTransitVirtualInterfaceCreate,
 		Read:   resourceAwsDxHostedTransitVirtualInterfaceRead,
+		Update: resourceAwsDxHostedTransitVirtualInterfaceUpdate,
 		Delete: resourceAwsDxHostedTransitVirtualInterfaceDelete,
 		Importer: &schema.ResourceImporter{
 			State: resourceAwsDxHostedTransitVirtualInterfaceImport,
@@ -46,36 +49,108 @@ func resourceAwsDxHostedTransitVirtualInterface() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"auto_accept": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
 			"aws_device": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"aws_logical_device_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"bgp_asn": {
-				Type:     schema.TypeInt,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDxBgpAsn,
 			},
 			"bgp_auth_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Computed:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
+			"bgp_auth_key_charset": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validateDxBgpAuthKeyCharset,
+				ConflictsWith: []string{"bgp_auth_key"},
+				RequiredWith:  []string{"bgp_auth_key_length"},
+			},
+			"bgp_auth_key_length": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validation.IntBetween(dxBgpAuthKeyMinLength, dxBgpAuthKeyMaxLength),
+				ConflictsWith: []string{"bgp_auth_key"},
+			},
+			"bgp_auth_key_set": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"bgp_peer_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"cloudwatch_dimensions": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"connection_encryption_status": {
 				Type:     schema.TypeString,
-				Optional: true,
 				Computed: true,
-				ForceNew: true,
 			},
 			"connection_id": {
 				Type:     schema.TypeString,
 				Required: true,
 				ForceNew: true,
 			},
+			"connection_owner_account_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"connection_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"customer_address": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
 				ForceNew: true,
 			},
+			"drain_before_delete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"dx_gateway_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
 			"jumbo_frame_capable": {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+			"location": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"mtu": {
 				Type:         schema.TypeInt,
 				Default:      1500,
@@ -84,9 +159,19 @@ func resourceAwsDxHostedTransitVirtualInterface() *schema.Resource {
 				ValidateFunc: validation.IntInSlice([]int{1500, 8500}),
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"name_prefix"},
+			},
+			"name_prefix": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"name"},
+				ValidateFunc:  validateDxVirtualInterfaceNamePrefix,
 			},
 			"owner_account_id": {
 				Type:         schema.TypeString,
@@ -94,18 +179,70 @@ func resourceAwsDxHostedTransitVirtualInterface() *schema.Resource {
 				ForceNew:     true,
 				ValidateFunc: validateAwsAccountId,
 			},
+			"partition": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"peer_addresses_consistent": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"prevent_active_delete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"skip_delete_wait": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"strict_address_family": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"virtual_interface_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"vlan": {
 				Type:         schema.TypeInt,
 				Required:     true,
 				ForceNew:     true,
 				ValidateFunc: validation.IntBetween(1, 4094),
 			},
+			"wait_for_connection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"warn_if_last_on_connection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
+
+		CustomizeDiff: customdiff.Sequence(
+			resourceAwsDxHostedTransitVirtualInterfaceCustomizeDiff,
+			dxHostedVirtualInterfaceAutoAcceptCustomizeDiff,
+			dxVirtualInterfaceCustomizeDiff,
+			dxVirtualInterfaceVlanCustomizeDiff,
+		),
 	}
 }
 
@@ -119,20 +256,34 @@ func resourceAwsDxHostedTransitVirtualInterfaceCreate(d *schema.ResourceData, me
 			AddressFamily:        aws.String(d.Get("address_family").(string)),
 			Asn:                  aws.Int64(int64(d.Get("bgp_asn").(int))),
 			Mtu:                  aws.Int64(int64(d.Get("mtu").(int))),
-			VirtualInterfaceName: aws.String(d.Get("name").(string)),
+			VirtualInterfaceName: aws.String(naming.Generate(d.Get("name").(string), d.Get("name_prefix").(string))),
 			Vlan:                 aws.Int64(int64(d.Get("vlan").(int))),
 		},
 	}
 	if v, ok := d.GetOk("amazon_address"); ok {
 		req.NewTransitVirtualInterfaceAllocation.AmazonAddress = aws.String(v.(string))
 	}
-	if v, ok := d.GetOk("bgp_auth_key"); ok {
-		req.NewTransitVirtualInterfaceAllocation.AuthKey = aws.String(v.(string))
+	authKey, err := dxVirtualInterfaceBgpAuthKey(d)
+	if err != nil {
+		return err
+	}
+	if authKey != "" {
+		req.NewTransitVirtualInterfaceAllocation.AuthKey = aws.String(authKey)
 	}
 	if v, ok := d.GetOk("customer_address"); ok {
 		req.NewTransitVirtualInterfaceAllocation.CustomerAddress = aws.String(v.(string))
 	}
 
+	if d.Get("wait_for_connection").(bool) {
+		if err := dxVirtualInterfaceWaitUntilConnectionAvailable(conn, d.Get("connection_id").(string), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
+	if err := dxVirtualInterfaceCheckVlanAvailable(conn, d.Get("connection_id").(string), d.Get("vlan").(int), ""); err != nil {
+		return err
+	}
+
 	log.Printf("[DEBUG] Creating Direct Connect hosted transit virtual interface: %s", req)
 	resp, err := conn.AllocateTransitVirtualInterface(req)
 	if err != nil {
@@ -140,11 +291,32 @@ func resourceAwsDxHostedTransitVirtualInterfaceCreate(d *schema.ResourceData, me
 	}
 
 	d.SetId(aws.StringValue(resp.VirtualInterface.VirtualInterfaceId))
+	d.Set("created_at", time.Now().UTC().Format(time.RFC3339))
 
 	if err := dxHostedTransitVirtualInterfaceWaitUntilAvailable(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
 		return err
 	}
 
+	if d.Get("auto_accept").(bool) {
+		confirmReq := &directconnect.ConfirmTransitVirtualInterfaceInput{
+			DirectConnectGatewayId: aws.String(d.Get("dx_gateway_id").(string)),
+			VirtualInterfaceId:     aws.String(d.Id()),
+		}
+
+		log.Printf("[DEBUG] Accepting Direct Connect hosted transit virtual interface: %s", confirmReq)
+		err := dxVirtualInterfaceConfirmWhenConfirmable(conn, d.Id(), d.Timeout(schema.TimeoutCreate), func() error {
+			_, err := conn.ConfirmTransitVirtualInterface(confirmReq)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("error accepting Direct Connect hosted transit virtual interface (%s): %s", d.Id(), err)
+		}
+
+		if err := dxHostedTransitVirtualInterfaceAccepterWaitUntilAvailable(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
 	return resourceAwsDxHostedTransitVirtualInterfaceRead(d, meta)
 }
 
@@ -161,31 +333,50 @@ func resourceAwsDxHostedTransitVirtualInterfaceRead(d *schema.ResourceData, meta
 		return nil
 	}
 
+	connection := dxVirtualInterfaceConnection(conn, vif)
+
 	d.Set("address_family", vif.AddressFamily)
 	d.Set("amazon_address", vif.AmazonAddress)
-	d.Set("amazon_side_asn", strconv.FormatInt(aws.Int64Value(vif.AmazonSideAsn), 10))
-	arn := arn.ARN{
-		Partition: meta.(*AWSClient).partition,
-		Region:    meta.(*AWSClient).region,
-		Service:   "directconnect",
-		AccountID: meta.(*AWSClient).accountid,
-		Resource:  fmt.Sprintf("dxvif/%s", d.Id()),
-	}.String()
+	d.Set("amazon_side_asn", strconv.FormatInt(dxTransitVirtualInterfaceAmazonSideAsn(conn, vif), 10))
+	arn := dxVirtualInterfaceArn(meta.(*AWSClient), meta.(*AWSClient).accountid, d.Id())
 	d.Set("arn", arn)
+	d.Set("partition", meta.(*AWSClient).partition)
 	d.Set("aws_device", vif.AwsDeviceV2)
+	d.Set("aws_logical_device_id", vif.AwsLogicalDeviceId)
 	d.Set("bgp_asn", vif.Asn)
 	d.Set("bgp_auth_key", vif.AuthKey)
+	d.Set("bgp_auth_key_set", aws.StringValue(vif.AuthKey) != "")
+	if err := d.Set("bgp_peer_ids", flattenDxVirtualInterfaceBgpPeerIds(vif)); err != nil {
+		return fmt.Errorf("error setting bgp_peer_ids: %w", err)
+	}
+	if err := d.Set("cloudwatch_dimensions", flattenDxVirtualInterfaceCloudWatchDimensions(vif)); err != nil {
+		return fmt.Errorf("error setting cloudwatch_dimensions: %w", err)
+	}
+	d.Set("connection_encryption_status", connection.PortEncryptionStatus)
 	d.Set("connection_id", vif.ConnectionId)
+	d.Set("connection_owner_account_id", connection.OwnerAccount)
+	d.Set("connection_state", connection.ConnectionState)
+	d.Set("location", connection.Location)
 	d.Set("customer_address", vif.CustomerAddress)
+	d.Set("peer_addresses_consistent", dxPeerAddressesConsistent(aws.StringValue(vif.AmazonAddress), aws.StringValue(vif.CustomerAddress)))
+	d.Set("dx_gateway_id", vif.DirectConnectGatewayId)
 	d.Set("jumbo_frame_capable", vif.JumboFrameCapable)
 	d.Set("mtu", vif.Mtu)
 	d.Set("name", vif.VirtualInterfaceName)
+	d.Set("name_prefix", naming.NamePrefixFromName(aws.StringValue(vif.VirtualInterfaceName)))
 	d.Set("owner_account_id", vif.OwnerAccount)
+	d.Set("state", aws.StringValue(vif.VirtualInterfaceState))
+	d.Set("role", dxVirtualInterfaceRole(vif, meta.(*AWSClient).accountid, false))
+	d.Set("virtual_interface_type", vif.VirtualInterfaceType)
 	d.Set("vlan", vif.Vlan)
 
 	return nil
 }
 
+func resourceAwsDxHostedTransitVirtualInterfaceUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceAwsDxHostedTransitVirtualInterfaceRead(d, meta)
+}
+
 func resourceAwsDxHostedTransitVirtualInterfaceDelete(d *schema.ResourceData, meta interface{}) error {
 	return dxVirtualInterfaceDelete(d, meta)
 }
@@ -205,9 +396,32 @@ func resourceAwsDxHostedTransitVirtualInterfaceImport(d *schema.ResourceData, me
 		return nil, fmt.Errorf("virtual interface (%s) has incorrect type: %s", d.Id(), vifType)
 	}
 
+	d.Set("auto_accept", false)
+	d.Set("drain_before_delete", false)
+	d.Set("prevent_active_delete", false)
+	d.Set("skip_delete_wait", false)
+	d.Set("strict_address_family", false)
+	d.Set("wait_for_connection", false)
+	d.Set("warn_if_last_on_connection", false)
+
 	return []*schema.ResourceData{d}, nil
 }
 
+func resourceAwsDxHostedTransitVirtualInterfaceCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	// GetOk reports an unknown gateway ID, e.g. that of a gateway created in the same run, as unset.
+	if diff.Id() == "" && diff.NewValueKnown("dx_gateway_id") {
+		// New resource.
+		_, dxgwOk := diff.GetOk("dx_gateway_id")
+		if autoAccept := diff.Get("auto_accept").(bool); autoAccept && !dxgwOk {
+			return fmt.Errorf("'dx_gateway_id' must be set when 'auto_accept' is enabled")
+		} else if !autoAccept && dxgwOk {
+			return fmt.Errorf("'dx_gateway_id' can only be set when 'auto_accept' is enabled")
+		}
+	}
+
+	return nil
+}
+
 func dxHostedTransitVirtualInterfaceWaitUntilAvailable(conn *directconnect.DirectConnect, vifId string, timeout time.Duration) error {
 	return dxVirtualInterfaceWaitUntilAvailable(
 		conn,
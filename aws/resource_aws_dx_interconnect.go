@@ -0,0 +1,255 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+)
+
+func resourceAwsDxInterconnect() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsDxInterconnectCreate,
+		Read:   resourceAwsDxInterconnectRead,
+		Update: resourceAwsDxInterconnectUpdate,
+		Delete: resourceAwsDxInterconnectDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"aws_device": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bandwidth": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDxConnectionBandWidth(),
+			},
+			"has_logical_redundancy": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"jumbo_frame_capable": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"lag_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"location": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"partition": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags":     tagsSchema(),
+			"tags_all": tagsSchemaComputed(),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		CustomizeDiff: SetTagsDiff,
+	}
+}
+
+func resourceAwsDxInterconnectCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dxconn
+	defaultTagsConfig := meta.(*AWSClient).DefaultTagsConfig
+	tags := defaultTagsConfig.MergeTags(keyvaluetags.New(d.Get("tags").(map[string]interface{})))
+
+	req := &directconnect.CreateInterconnectInput{
+		Bandwidth:        aws.String(d.Get("bandwidth").(string)),
+		InterconnectName: aws.String(d.Get("name").(string)),
+		Location:         aws.String(d.Get("location").(string)),
+	}
+
+	if v, ok := d.GetOk("lag_id"); ok {
+		req.LagId = aws.String(v.(string))
+	}
+
+	if len(tags) > 0 {
+		req.Tags = tags.IgnoreAws().DirectconnectTags()
+	}
+
+	log.Printf("[DEBUG] Creating Direct Connect interconnect: %s", req)
+	resp, err := conn.CreateInterconnect(req)
+	if err != nil {
+		return fmt.Errorf("error creating Direct Connect interconnect: %s", err)
+	}
+
+	d.SetId(aws.StringValue(resp.InterconnectId))
+
+	// A new interconnect remains "requested" or "pending" until its LOA-CFA has been issued and the cross connect
+	// completed, which can take days, so it is not waited on.
+	return resourceAwsDxInterconnectRead(d, meta)
+}
+
+func resourceAwsDxInterconnectRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dxconn
+	defaultTagsConfig := meta.(*AWSClient).DefaultTagsConfig
+	ignoreTagsConfig := meta.(*AWSClient).IgnoreTagsConfig
+
+	resp, err := conn.DescribeInterconnects(&directconnect.DescribeInterconnectsInput{
+		InterconnectId: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isNoSuchDxInterconnectErr(err) {
+			log.Printf("[WARN] Direct Connect interconnect (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("error reading Direct Connect interconnect (%s): %s", d.Id(), err)
+	}
+
+	if len(resp.Interconnects) < 1 {
+		log.Printf("[WARN] Direct Connect interconnect (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if len(resp.Interconnects) != 1 {
+		return fmt.Errorf("Number of Direct Connect interconnects (%s) isn't one, got %d", d.Id(), len(resp.Interconnects))
+	}
+	interconnect := resp.Interconnects[0]
+	if d.Id() != aws.StringValue(interconnect.InterconnectId) {
+		return fmt.Errorf("Direct Connect interconnect (%s) not found", d.Id())
+	}
+	if aws.StringValue(interconnect.InterconnectState) == directconnect.InterconnectStateDeleted {
+		log.Printf("[WARN] Direct Connect interconnect (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	arn := arn.ARN{
+		Partition: meta.(*AWSClient).partition,
+		Region:    meta.(*AWSClient).region,
+		Service:   "directconnect",
+		AccountID: meta.(*AWSClient).accountid,
+		Resource:  fmt.Sprintf("dxcon/%s", d.Id()),
+	}.String()
+	d.Set("arn", arn)
+	d.Set("partition", meta.(*AWSClient).partition)
+	d.Set("aws_device", interconnect.AwsDeviceV2)
+	d.Set("bandwidth", interconnect.Bandwidth)
+	d.Set("has_logical_redundancy", interconnect.HasLogicalRedundancy)
+	d.Set("jumbo_frame_capable", interconnect.JumboFrameCapable)
+	d.Set("lag_id", interconnect.LagId)
+	d.Set("location", interconnect.Location)
+	d.Set("name", interconnect.InterconnectName)
+
+	tags, err := keyvaluetags.DirectconnectListTags(conn, arn)
+
+	if err != nil {
+		return fmt.Errorf("error listing tags for Direct Connect interconnect (%s): %s", arn, err)
+	}
+
+	tags = dxFilterSystemTags(tags, ignoreTagsConfig)
+
+	//lintignore:AWSR002
+	if err := d.Set("tags", tags.RemoveDefaultConfig(defaultTagsConfig).Map()); err != nil {
+		return fmt.Errorf("error setting tags: %w", err)
+	}
+
+	if err := d.Set("tags_all", tags.Map()); err != nil {
+		return fmt.Errorf("error setting tags_all: %w", err)
+	}
+
+	return nil
+}
+
+func resourceAwsDxInterconnectUpdate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dxconn
+
+	arn := d.Get("arn").(string)
+	if d.HasChange("tags_all") {
+		o, n := d.GetChange("tags_all")
+
+		if err := keyvaluetags.DirectconnectUpdateTags(conn, arn, o, n); err != nil {
+			return fmt.Errorf("error updating Direct Connect interconnect (%s) tags: %s", arn, err)
+		}
+	}
+
+	return resourceAwsDxInterconnectRead(d, meta)
+}
+
+func resourceAwsDxInterconnectDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).dxconn
+
+	log.Printf("[DEBUG] Deleting Direct Connect interconnect: %s", d.Id())
+	_, err := conn.DeleteInterconnect(&directconnect.DeleteInterconnectInput{
+		InterconnectId: aws.String(d.Id()),
+	})
+	if err != nil {
+		if isNoSuchDxInterconnectErr(err) {
+			return nil
+		}
+		return fmt.Errorf("error deleting Direct Connect interconnect (%s): %s", d.Id(), err)
+	}
+
+	deleteStateConf := &resource.StateChangeConf{
+		Pending: []string{
+			directconnect.InterconnectStateAvailable,
+			directconnect.InterconnectStateDeleting,
+			directconnect.InterconnectStateDown,
+			directconnect.InterconnectStatePending,
+			directconnect.InterconnectStateRequested,
+		},
+		Target:     []string{directconnect.InterconnectStateDeleted},
+		Refresh:    dxInterconnectRefreshStateFunc(conn, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+	_, err = deleteStateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("error waiting for Direct Connect interconnect (%s) to be deleted: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func dxInterconnectRefreshStateFunc(conn *directconnect.DirectConnect, interconnectId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := conn.DescribeInterconnects(&directconnect.DescribeInterconnectsInput{
+			InterconnectId: aws.String(interconnectId),
+		})
+		if isNoSuchDxInterconnectErr(err) {
+			return "", directconnect.InterconnectStateDeleted, nil
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		if len(resp.Interconnects) < 1 {
+			return "", directconnect.InterconnectStateDeleted, nil
+		}
+		return resp.Interconnects[0], aws.StringValue(resp.Interconnects[0].InterconnectState), nil
+	}
+}
+
+func isNoSuchDxInterconnectErr(err error) bool {
+	return isAWSErr(err, directconnect.ErrCodeClientException, "Could not find Interconnect with ID")
+}
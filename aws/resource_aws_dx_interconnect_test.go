@@ -0,0 +1,102 @@
+package aws
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAWSDxInterconnect_basic(t *testing.T) {
+	key := "DX_INTERCONNECT_LOCATION"
+	location := os.Getenv(key)
+	if location == "" {
+		t.Skipf("Environment variable %s is not set", key)
+	}
+
+	rName := fmt.Sprintf("tf-testacc-dx-%s", acctest.RandString(9))
+	resourceName := "aws_dx_interconnect.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		ErrorCheck:   testAccErrorCheck(t, directconnect.EndpointsID),
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDxInterconnectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDxInterconnectConfig(rName, location),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDxInterconnectExists(resourceName),
+					testAccMatchResourceAttrRegionalARN(resourceName, "arn", "directconnect", regexp.MustCompile(`dxcon/.+`)),
+					resource.TestCheckResourceAttr(resourceName, "bandwidth", "1Gbps"),
+					resource.TestCheckResourceAttr(resourceName, "location", location),
+					resource.TestCheckResourceAttr(resourceName, "name", rName),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "0"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckAwsDxInterconnectDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).dxconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_dx_interconnect" {
+			continue
+		}
+
+		resp, err := conn.DescribeInterconnects(&directconnect.DescribeInterconnectsInput{
+			InterconnectId: aws.String(rs.Primary.ID),
+		})
+		if isNoSuchDxInterconnectErr(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, v := range resp.Interconnects {
+			if aws.StringValue(v.InterconnectId) == rs.Primary.ID && aws.StringValue(v.InterconnectState) != directconnect.InterconnectStateDeleted {
+				return fmt.Errorf("[DESTROY ERROR] Direct Connect interconnect (%s) not deleted", rs.Primary.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAwsDxInterconnectExists(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		return nil
+	}
+}
+
+func testAccDxInterconnectConfig(rName, location string) string {
+	return fmt.Sprintf(`
+resource "aws_dx_interconnect" "test" {
+  name      = %[1]q
+  bandwidth = "1Gbps"
+  location  = %[2]q
+}
+`, rName, location)
+}
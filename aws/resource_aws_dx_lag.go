@@ -1,6 +1,7 @@
 package aws
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -8,8 +9,10 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
 )
 
@@ -32,6 +35,12 @@ func resourceAwsDxLag() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"connection_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			"connections_bandwidth": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -43,6 +52,12 @@ func resourceAwsDxLag() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"min_links": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 			"force_destroy": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -52,6 +67,10 @@ func resourceAwsDxLag() *schema.Resource {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+			"partition": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"tags":     tagsSchema(),
 			"tags_all": tagsSchemaComputed(),
 			"has_logical_redundancy": {
@@ -60,8 +79,31 @@ func resourceAwsDxLag() *schema.Resource {
 			},
 		},
 
-		CustomizeDiff: SetTagsDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		CustomizeDiff: customdiff.Sequence(
+			SetTagsDiff,
+			dxLagConnectionIdsCustomizeDiff,
+		),
+	}
+}
+
+// dxLagConnectionIdsCustomizeDiff returns an error if the member connections of an existing LAG change
+// such that fewer remain than the minimum number of operational connections.
+func dxLagConnectionIdsCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !diff.HasChanges("connection_ids", "min_links") || !diff.NewValueKnown("connection_ids") {
+		return nil
+	}
+
+	if n, minLinks := diff.Get("connection_ids").(*schema.Set).Len(), diff.Get("min_links").(int); n < minLinks {
+		return fmt.Errorf("Direct Connect LAG (%s) must retain at least 'min_links' (%d) connections, got %d 'connection_ids'", diff.Id(), minLinks, n)
 	}
+
+	return nil
 }
 
 func resourceAwsDxLagCreate(d *schema.ResourceData, meta interface{}) error {
@@ -99,6 +141,28 @@ func resourceAwsDxLagCreate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("error deleting newly created and unmanaged Direct Connect LAG (%s) Connection (%s): %s", d.Id(), connectionID, err)
 	}
 
+	if v, ok := d.GetOk("connection_ids"); ok {
+		if err := dxLagAssociateConnections(conn, d.Id(), expandStringSet(v.(*schema.Set)), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
+	if v, ok := d.GetOk("min_links"); ok {
+		req := &directconnect.UpdateLagInput{
+			LagId:        aws.String(d.Id()),
+			MinimumLinks: aws.Int64(int64(v.(int))),
+		}
+
+		log.Printf("[DEBUG] Updating Direct Connect LAG: %#v", req)
+		if _, err := conn.UpdateLag(req); err != nil {
+			return fmt.Errorf("error updating Direct Connect LAG (%s) minimum links: %s", d.Id(), err)
+		}
+	}
+
+	if err := dxLagWaitUntilCreated(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return err
+	}
+
 	return resourceAwsDxLagRead(d, meta)
 }
 
@@ -146,19 +210,33 @@ func resourceAwsDxLagRead(d *schema.ResourceData, meta interface{}) error {
 		Resource:  fmt.Sprintf("dxlag/%s", d.Id()),
 	}.String()
 	d.Set("arn", arn)
+	d.Set("partition", meta.(*AWSClient).partition)
 	d.Set("name", lag.LagName)
 	d.Set("connections_bandwidth", lag.ConnectionsBandwidth)
 	d.Set("location", lag.Location)
+	d.Set("min_links", lag.MinimumLinks)
 	d.Set("jumbo_frame_capable", lag.JumboFrameCapable)
 	d.Set("has_logical_redundancy", lag.HasLogicalRedundancy)
 
+	var connectionIds []string
+	for _, connection := range lag.Connections {
+		if aws.StringValue(connection.ConnectionState) == directconnect.ConnectionStateDeleted {
+			continue
+		}
+
+		connectionIds = append(connectionIds, aws.StringValue(connection.ConnectionId))
+	}
+	if err := d.Set("connection_ids", connectionIds); err != nil {
+		return fmt.Errorf("error setting connection_ids: %w", err)
+	}
+
 	tags, err := keyvaluetags.DirectconnectListTags(conn, arn)
 
 	if err != nil {
 		return fmt.Errorf("error listing tags for Direct Connect LAG (%s): %s", arn, err)
 	}
 
-	tags = tags.IgnoreAws().IgnoreConfig(ignoreTagsConfig)
+	tags = dxFilterSystemTags(tags, ignoreTagsConfig)
 
 	//lintignore:AWSR002
 	if err := d.Set("tags", tags.RemoveDefaultConfig(defaultTagsConfig).Map()); err != nil {
@@ -175,10 +253,31 @@ func resourceAwsDxLagRead(d *schema.ResourceData, meta interface{}) error {
 func resourceAwsDxLagUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).dxconn
 
-	if d.HasChange("name") {
+	// Connections are added before and removed after any change to the minimum number of operational connections,
+	// so that the LAG retains at least that many connections throughout.
+	var removeConnectionIds []*string
+	if d.HasChange("connection_ids") {
+		o, n := d.GetChange("connection_ids")
+		os, ns := o.(*schema.Set), n.(*schema.Set)
+
+		if err := dxLagAssociateConnections(conn, d.Id(), expandStringSet(ns.Difference(os)), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+
+		removeConnectionIds = expandStringSet(os.Difference(ns))
+	}
+
+	if d.HasChanges("min_links", "name") {
 		req := &directconnect.UpdateLagInput{
-			LagId:   aws.String(d.Id()),
-			LagName: aws.String(d.Get("name").(string)),
+			LagId: aws.String(d.Id()),
+		}
+
+		if d.HasChange("min_links") {
+			req.MinimumLinks = aws.Int64(int64(d.Get("min_links").(int)))
+		}
+
+		if d.HasChange("name") {
+			req.LagName = aws.String(d.Get("name").(string))
 		}
 
 		log.Printf("[DEBUG] Updating Direct Connect LAG: %#v", req)
@@ -186,6 +285,21 @@ func resourceAwsDxLagUpdate(d *schema.ResourceData, meta interface{}) error {
 		if err != nil {
 			return fmt.Errorf("error updating Direct Connect LAG (%s): %s", d.Id(), err)
 		}
+
+		if err := dxLagWaitUntilUpdated(conn, req, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return err
+		}
+	}
+
+	for _, connectionId := range aws.StringValueSlice(removeConnectionIds) {
+		log.Printf("[DEBUG] Disassociating Direct Connect connection (%s) from LAG (%s)", connectionId, d.Id())
+		if err := dxConnectionDisassociateFromLag(conn, connectionId, d.Id()); err != nil {
+			return fmt.Errorf("error disassociating Direct Connect connection (%s) from LAG (%s): %s", connectionId, d.Id(), err)
+		}
+
+		if err := dxConnectionWaitForLagId(conn, connectionId, d.Id(), "", d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return fmt.Errorf("error waiting for Direct Connect connection (%s) to be disassociated from LAG (%s): %s", connectionId, d.Id(), err)
+		}
 	}
 
 	arn := d.Get("arn").(string)
@@ -244,7 +358,7 @@ func resourceAwsDxLagDelete(d *schema.ResourceData, meta interface{}) error {
 		Pending:    []string{directconnect.LagStateAvailable, directconnect.LagStateRequested, directconnect.LagStatePending, directconnect.LagStateDeleting},
 		Target:     []string{directconnect.LagStateDeleted},
 		Refresh:    dxLagRefreshStateFunc(conn, d.Id()),
-		Timeout:    10 * time.Minute,
+		Timeout:    d.Timeout(schema.TimeoutDelete),
 		Delay:      10 * time.Second,
 		MinTimeout: 3 * time.Second,
 	}
@@ -256,6 +370,59 @@ func resourceAwsDxLagDelete(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// dxLagAssociateConnections associates the specified connections, which must not be associated with another LAG, with a LAG.
+func dxLagAssociateConnections(conn *directconnect.DirectConnect, lagId string, connectionIds []*string, timeout time.Duration) error {
+	for _, connectionId := range aws.StringValueSlice(connectionIds) {
+		log.Printf("[DEBUG] Associating Direct Connect connection (%s) with LAG (%s)", connectionId, lagId)
+		_, err := conn.AssociateConnectionWithLag(&directconnect.AssociateConnectionWithLagInput{
+			ConnectionId: aws.String(connectionId),
+			LagId:        aws.String(lagId),
+		})
+		if err != nil {
+			return fmt.Errorf("error associating Direct Connect connection (%s) with LAG (%s): %s", connectionId, lagId, err)
+		}
+
+		if err := dxConnectionWaitForLagId(conn, connectionId, "", lagId, timeout); err != nil {
+			return fmt.Errorf("error waiting for Direct Connect connection (%s) to be associated with LAG (%s): %s", connectionId, lagId, err)
+		}
+	}
+
+	return nil
+}
+
+// dxLagCheckMinimumLinksWithout returns an error if disassociating the specified connection from a LAG would leave
+// the LAG with fewer operational connections than its minimum links, as the LAG would then go down.
+// Nothing is checked if the LAG cannot be found.
+func dxLagCheckMinimumLinksWithout(conn *directconnect.DirectConnect, lagId, connectionId string) error {
+	resp, err := conn.DescribeLags(&directconnect.DescribeLagsInput{
+		LagId: aws.String(lagId),
+	})
+	if isNoSuchDxLagErr(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error reading Direct Connect LAG (%s): %s", lagId, err)
+	}
+	if len(resp.Lags) < 1 {
+		return nil
+	}
+
+	lag := resp.Lags[0]
+	minLinks := int(aws.Int64Value(lag.MinimumLinks))
+	remaining := 0
+	for _, connection := range lag.Connections {
+		if aws.StringValue(connection.ConnectionId) != connectionId && aws.StringValue(connection.ConnectionState) == directconnect.ConnectionStateAvailable {
+			remaining++
+		}
+	}
+
+	if remaining < minLinks {
+		return fmt.Errorf("disassociating Direct Connect connection (%s) from LAG (%s) would leave %d operational connections, fewer than the LAG's 'min_links' (%d); lower the LAG's 'min_links' or add connections to it first", connectionId, lagId, remaining, minLinks)
+	}
+
+	return nil
+}
+
 func dxLagRefreshStateFunc(conn *directconnect.DirectConnect, lagId string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		input := &directconnect.DescribeLagsInput{
@@ -272,6 +439,82 @@ func dxLagRefreshStateFunc(conn *directconnect.DirectConnect, lagId string) reso
 	}
 }
 
+// dxLagWaitUntilCreated waits until a newly created LAG is visible.
+// A LAG remains "requested" or "pending" until the cross connects of its connections are completed, which can take days,
+// so any state other than deleted is accepted.
+func dxLagWaitUntilCreated(conn *directconnect.DirectConnect, lagId string, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{},
+		Target: []string{
+			directconnect.LagStateAvailable,
+			directconnect.LagStateDown,
+			directconnect.LagStatePending,
+			directconnect.LagStateRequested,
+		},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := conn.DescribeLags(&directconnect.DescribeLagsInput{
+				LagId: aws.String(lagId),
+			})
+			if isNoSuchDxLagErr(err) {
+				return nil, "", nil
+			}
+			if err != nil {
+				return nil, "", err
+			}
+
+			// A newly created LAG may be transiently missing from DescribeLags.
+			if len(resp.Lags) < 1 {
+				log.Printf("[DEBUG] Direct Connect LAG (%s) not found", lagId)
+				return nil, "", nil
+			}
+
+			lag := resp.Lags[0]
+			return lag, aws.StringValue(lag.LagState), nil
+		},
+		Timeout:        timeout,
+		MinTimeout:     5 * time.Second,
+		NotFoundChecks: 5,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("error waiting for Direct Connect LAG (%s) to be created: %s", lagId, err)
+	}
+
+	return nil
+}
+
+// dxLagWaitUntilUpdated waits until the minimum links and name of the LAG reflect an update.
+// The LAG's state is not waited on as it does not depend on the update.
+func dxLagWaitUntilUpdated(conn *directconnect.DirectConnect, input *directconnect.UpdateLagInput, timeout time.Duration) error {
+	lagId := aws.StringValue(input.LagId)
+
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		resp, err := conn.DescribeLags(&directconnect.DescribeLagsInput{
+			LagId: input.LagId,
+		})
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if len(resp.Lags) < 1 {
+			return resource.NonRetryableError(fmt.Errorf("Direct Connect LAG (%s) not found", lagId))
+		}
+
+		lag := resp.Lags[0]
+		if input.MinimumLinks != nil && aws.Int64Value(lag.MinimumLinks) != aws.Int64Value(input.MinimumLinks) {
+			return resource.RetryableError(fmt.Errorf("Direct Connect LAG (%s) minimum links is %d, expected %d", lagId, aws.Int64Value(lag.MinimumLinks), aws.Int64Value(input.MinimumLinks)))
+		}
+		if input.LagName != nil && aws.StringValue(lag.LagName) != aws.StringValue(input.LagName) {
+			return resource.RetryableError(fmt.Errorf("Direct Connect LAG (%s) name is %q, expected %q", lagId, aws.StringValue(lag.LagName), aws.StringValue(input.LagName)))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for Direct Connect LAG (%s) to be updated: %s", lagId, err)
+	}
+
+	return nil
+}
+
 func isNoSuchDxLagErr(err error) bool {
 	return isAWSErr(err, "DirectConnectClientException", "Could not find Lag with ID")
 }
@@ -3,9 +3,12 @@ package aws
 import (
 	"fmt"
 	"log"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/directconnect"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -165,6 +168,207 @@ func TestAccAWSDxLag_tags(t *testing.T) {
 	})
 }
 
+func TestAccAWSDxLag_ConnectionIds(t *testing.T) {
+	lagName := fmt.Sprintf("tf-dx-lag-%s", acctest.RandString(5))
+	resourceName := "aws_dx_lag.test"
+	connectionResourceName1 := "aws_dx_connection.test1"
+	connectionResourceName2 := "aws_dx_connection.test2"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		ErrorCheck:   testAccErrorCheck(t, directconnect.EndpointsID),
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDxLagDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDxLagConfig_connectionIds(lagName, "aws_dx_connection.test1.id, aws_dx_connection.test2.id"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDxLagExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "connection_ids.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "connection_ids.*", connectionResourceName1, "id"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "connection_ids.*", connectionResourceName2, "id"),
+				),
+			},
+			{
+				Config: testAccDxLagConfig_connectionIds(lagName, "aws_dx_connection.test1.id"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDxLagExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "connection_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(resourceName, "connection_ids.*", connectionResourceName1, "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestDxLagAssociateConnections(t *testing.T) {
+	var operations []string
+	lagIds := map[string]string{}
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.Connection:
+			operations = append(operations, r.Operation.Name)
+			input := r.Params.(*directconnect.AssociateConnectionWithLagInput)
+			lagIds[aws.StringValue(input.ConnectionId)] = aws.StringValue(input.LagId)
+		case *directconnect.Connections:
+			connectionId := aws.StringValue(r.Params.(*directconnect.DescribeConnectionsInput).ConnectionId)
+			data.Connections = []*directconnect.Connection{{
+				ConnectionId: aws.String(connectionId),
+				LagId:        aws.String(lagIds[connectionId]),
+			}}
+		}
+	})
+
+	if err := dxLagAssociateConnections(conn, "dxlag-12345678", aws.StringSlice([]string{"dxcon-00000001", "dxcon-00000002"}), 1*time.Minute); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected := []string{"AssociateConnectionWithLag", "AssociateConnectionWithLag"}; !reflect.DeepEqual(operations, expected) {
+		t.Errorf("expected operations %v, got %v", expected, operations)
+	}
+
+	if expected := map[string]string{"dxcon-00000001": "dxlag-12345678", "dxcon-00000002": "dxlag-12345678"}; !reflect.DeepEqual(lagIds, expected) {
+		t.Errorf("expected LAG IDs %v, got %v", expected, lagIds)
+	}
+}
+
+func TestDxLagCreate_unprovisioned(t *testing.T) {
+	var operations []string
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		operations = append(operations, r.Operation.Name)
+
+		switch data := r.Data.(type) {
+		case *directconnect.Lag:
+			data.Connections = []*directconnect.Connection{{
+				ConnectionId: aws.String("dxcon-12345678"),
+			}}
+			data.LagId = aws.String("dxlag-12345678")
+		case *directconnect.DescribeLagsOutput:
+			// The LAG's connections are awaiting their cross connects.
+			data.Lags = []*directconnect.Lag{{
+				LagId:    aws.String("dxlag-12345678"),
+				LagName:  aws.String("test"),
+				LagState: aws.String(directconnect.LagStateRequested),
+			}}
+		case *directconnect.DescribeTagsOutput:
+			data.ResourceTags = []*directconnect.ResourceTag{{}}
+		}
+	})
+
+	d := resourceAwsDxLag().Data(nil)
+	d.Set("connections_bandwidth", "1Gbps")
+	d.Set("location", "EqSe2-EQ")
+	d.Set("name", "test")
+
+	if err := resourceAwsDxLagCreate(d, &AWSClient{dxconn: conn, partition: "aws", region: "us-west-2", accountid: "123456789012"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, expected := d.Id(), "dxlag-12345678"; got != expected {
+		t.Errorf("got ID %s, expected %s", got, expected)
+	}
+
+	if expected := []string{"CreateLag", "DeleteConnection", "DescribeLags", "DescribeLags", "DescribeTags"}; !reflect.DeepEqual(operations, expected) {
+		t.Errorf("expected operations %v, got %v", expected, operations)
+	}
+}
+
+func TestDxLagWaitUntilUpdated(t *testing.T) {
+	describeLagsCalls := 0
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeLagsOutput:
+			describeLagsCalls++
+			lag := &directconnect.Lag{
+				LagId:        aws.String("dxlag-12345678"),
+				LagName:      aws.String("test"),
+				LagState:     aws.String(directconnect.LagStatePending),
+				MinimumLinks: aws.Int64(0),
+			}
+			// The update is only visible from the second read.
+			if describeLagsCalls > 1 {
+				lag.MinimumLinks = aws.Int64(2)
+			}
+			data.Lags = []*directconnect.Lag{lag}
+		}
+	})
+
+	input := &directconnect.UpdateLagInput{
+		LagId:        aws.String("dxlag-12345678"),
+		MinimumLinks: aws.Int64(2),
+	}
+
+	// The LAG's state is not waited on.
+	if err := dxLagWaitUntilUpdated(conn, input, 1*time.Minute); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if describeLagsCalls != 2 {
+		t.Errorf("expected DescribeLags to be called 2 times, got %d", describeLagsCalls)
+	}
+}
+
+func TestDxLagCheckMinimumLinksWithout(t *testing.T) {
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeLagsOutput:
+			data.Lags = []*directconnect.Lag{{
+				Connections: []*directconnect.Connection{
+					{
+						ConnectionId:    aws.String("dxcon-00000001"),
+						ConnectionState: aws.String(directconnect.ConnectionStateAvailable),
+					},
+					{
+						ConnectionId:    aws.String("dxcon-00000002"),
+						ConnectionState: aws.String(directconnect.ConnectionStateAvailable),
+					},
+					{
+						ConnectionId:    aws.String("dxcon-00000003"),
+						ConnectionState: aws.String(directconnect.ConnectionStateDown),
+					},
+				},
+				LagId:        aws.String("dxlag-12345678"),
+				MinimumLinks: aws.Int64(1),
+			}}
+		}
+	})
+
+	// One operational connection remains.
+	if err := dxLagCheckMinimumLinksWithout(conn, "dxlag-12345678", "dxcon-00000001"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// The connection that is down does not count towards the minimum links.
+	if err := dxLagCheckMinimumLinksWithout(conn, "dxlag-12345678", "dxcon-00000003"); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	conn = testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeLagsOutput:
+			data.Lags = []*directconnect.Lag{{
+				Connections: []*directconnect.Connection{
+					{
+						ConnectionId:    aws.String("dxcon-00000001"),
+						ConnectionState: aws.String(directconnect.ConnectionStateAvailable),
+					},
+					{
+						ConnectionId:    aws.String("dxcon-00000002"),
+						ConnectionState: aws.String(directconnect.ConnectionStateDown),
+					},
+				},
+				LagId:        aws.String("dxlag-12345678"),
+				MinimumLinks: aws.Int64(1),
+			}}
+		}
+	})
+
+	// No operational connection would remain.
+	if err := dxLagCheckMinimumLinksWithout(conn, "dxlag-12345678", "dxcon-00000001"); err == nil {
+		t.Error("expected error, got none")
+	}
+}
+
 func testAccCheckAwsDxLagDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*AWSClient).dxconn
 
@@ -243,3 +447,28 @@ resource "aws_dx_lag" "test" {
 }
 `, n)
 }
+
+func testAccDxLagConfig_connectionIds(n, connectionIds string) string {
+	return fmt.Sprintf(`
+resource "aws_dx_connection" "test1" {
+  name      = "%[1]s-1"
+  bandwidth = "1Gbps"
+  location  = "EqSe2-EQ"
+}
+
+resource "aws_dx_connection" "test2" {
+  name      = "%[1]s-2"
+  bandwidth = "1Gbps"
+  location  = "EqSe2-EQ"
+}
+
+resource "aws_dx_lag" "test" {
+  name                  = %[1]q
+  connections_bandwidth = "1Gbps"
+  location              = "EqSe2-EQ"
+  force_destroy         = true
+
+  connection_ids = [%[2]s]
+}
+`, n, connectionIds)
+}
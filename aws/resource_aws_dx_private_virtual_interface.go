@@ -7,11 +7,12 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/directconnect"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/naming"
 )
 
 func resourceAwsDxPrivateVirtualInterface() *schema.Resource {
@@ -52,38 +53,112 @@ func resourceAwsDxPrivateVirtualInterface() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"aws_logical_device_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"bgp_asn": {
-				Type:     schema.TypeInt,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDxBgpAsn,
 			},
 			"bgp_auth_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Computed:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
+			"bgp_auth_key_charset": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validateDxBgpAuthKeyCharset,
+				ConflictsWith: []string{"bgp_auth_key"},
+				RequiredWith:  []string{"bgp_auth_key_length"},
+			},
+			"bgp_auth_key_length": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validation.IntBetween(dxBgpAuthKeyMinLength, dxBgpAuthKeyMaxLength),
+				ConflictsWith: []string{"bgp_auth_key"},
+			},
+			"bgp_auth_key_set": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"bgp_peer_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"cloudwatch_dimensions": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"connection_encryption_status": {
 				Type:     schema.TypeString,
-				Optional: true,
 				Computed: true,
-				ForceNew: true,
 			},
 			"connection_id": {
 				Type:     schema.TypeString,
 				Required: true,
 				ForceNew: true,
 			},
+			"connection_owner_account_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"connection_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"customer_address": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
 				ForceNew: true,
 			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"drain_before_delete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"dx_gateway_id": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				ForceNew:      true,
 				ConflictsWith: []string{"vpn_gateway_id"},
 			},
+			"ignore_tag_keys": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"ipv6_address_assignment": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(dxIpv6AddressAssignments, false),
+			},
 			"jumbo_frame_capable": {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+			"location": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"mtu": {
 				Type:         schema.TypeInt,
 				Default:      1500,
@@ -91,12 +166,75 @@ func resourceAwsDxPrivateVirtualInterface() *schema.Resource {
 				ValidateFunc: validation.IntInSlice([]int{1500, 9001}),
 			},
 			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"name_prefix"},
+			},
+			"name_prefix": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"name"},
+				ValidateFunc:  validateDxVirtualInterfaceNamePrefix,
+			},
+			"owner_account_id": {
 				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Computed: true,
+			},
+			"partition": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"peer_addresses_consistent": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"prevent_active_delete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"required_tag_keys": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"router_config": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"router_type_identifier": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"skip_delete_wait": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"strict_address_family": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
 			},
 			"tags":     tagsSchema(),
 			"tags_all": tagsSchemaComputed(),
+			"virtual_interface_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"vlan": {
 				Type:         schema.TypeInt,
 				Required:     true,
@@ -109,6 +247,21 @@ func resourceAwsDxPrivateVirtualInterface() *schema.Resource {
 				ForceNew:      true,
 				ConflictsWith: []string{"dx_gateway_id"},
 			},
+			"wait_for_bgp_up": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"wait_for_connection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"warn_if_last_on_connection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -117,7 +270,17 @@ func resourceAwsDxPrivateVirtualInterface() *schema.Resource {
 			Delete: schema.DefaultTimeout(10 * time.Minute),
 		},
 
-		CustomizeDiff: SetTagsDiff,
+		CustomizeDiff: customdiff.Sequence(
+			dxVirtualInterfaceCustomizeDiff,
+			dxVirtualInterfaceDisplayNameCustomizeDiff,
+			dxVirtualInterfaceGatewayMigrationCustomizeDiff("dx_gateway_id", "vpn_gateway_id"),
+			dxVirtualInterfaceIpv6AddressAssignmentCustomizeDiff,
+			dxVirtualInterfaceJumboFrameCustomizeDiff,
+			dxVirtualInterfaceVlanCustomizeDiff,
+			SetTagsDiff,
+			dxVirtualInterfaceIgnoreTagKeysCustomizeDiff,
+			dxVirtualInterfaceRequiredTagsCustomizeDiff,
+		),
 	}
 }
 
@@ -125,6 +288,7 @@ func resourceAwsDxPrivateVirtualInterfaceCreate(d *schema.ResourceData, meta int
 	conn := meta.(*AWSClient).dxconn
 	defaultTagsConfig := meta.(*AWSClient).DefaultTagsConfig
 	tags := defaultTagsConfig.MergeTags(keyvaluetags.New(d.Get("tags").(map[string]interface{})))
+	tags = tags.Merge(dxVirtualInterfaceDisplayNameTags(d.Get("display_name").(string)))
 
 	vgwIdRaw, vgwOk := d.GetOk("vpn_gateway_id")
 	dxgwIdRaw, dxgwOk := d.GetOk("dx_gateway_id")
@@ -139,7 +303,7 @@ func resourceAwsDxPrivateVirtualInterfaceCreate(d *schema.ResourceData, meta int
 			AddressFamily:        aws.String(d.Get("address_family").(string)),
 			Asn:                  aws.Int64(int64(d.Get("bgp_asn").(int))),
 			Mtu:                  aws.Int64(int64(d.Get("mtu").(int))),
-			VirtualInterfaceName: aws.String(d.Get("name").(string)),
+			VirtualInterfaceName: aws.String(naming.Generate(d.Get("name").(string), d.Get("name_prefix").(string))),
 			Vlan:                 aws.Int64(int64(d.Get("vlan").(int))),
 		},
 	}
@@ -152,8 +316,12 @@ func resourceAwsDxPrivateVirtualInterfaceCreate(d *schema.ResourceData, meta int
 	if v, ok := d.GetOk("amazon_address"); ok {
 		req.NewPrivateVirtualInterface.AmazonAddress = aws.String(v.(string))
 	}
-	if v, ok := d.GetOk("bgp_auth_key"); ok {
-		req.NewPrivateVirtualInterface.AuthKey = aws.String(v.(string))
+	authKey, err := dxVirtualInterfaceBgpAuthKey(d)
+	if err != nil {
+		return err
+	}
+	if authKey != "" {
+		req.NewPrivateVirtualInterface.AuthKey = aws.String(authKey)
 	}
 	if v, ok := d.GetOk("customer_address"); ok {
 		req.NewPrivateVirtualInterface.CustomerAddress = aws.String(v.(string))
@@ -162,6 +330,16 @@ func resourceAwsDxPrivateVirtualInterfaceCreate(d *schema.ResourceData, meta int
 		req.NewPrivateVirtualInterface.Tags = tags.IgnoreAws().DirectconnectTags()
 	}
 
+	if d.Get("wait_for_connection").(bool) {
+		if err := dxVirtualInterfaceWaitUntilConnectionAvailable(conn, d.Get("connection_id").(string), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
+	if err := dxVirtualInterfaceCheckVlanAvailable(conn, d.Get("connection_id").(string), d.Get("vlan").(int), ""); err != nil {
+		return err
+	}
+
 	log.Printf("[DEBUG] Creating Direct Connect private virtual interface: %s", req)
 	resp, err := conn.CreatePrivateVirtualInterface(req)
 	if err != nil {
@@ -169,11 +347,18 @@ func resourceAwsDxPrivateVirtualInterfaceCreate(d *schema.ResourceData, meta int
 	}
 
 	d.SetId(aws.StringValue(resp.VirtualInterfaceId))
+	d.Set("created_at", time.Now().UTC().Format(time.RFC3339))
 
 	if err := dxPrivateVirtualInterfaceWaitUntilAvailable(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
 		return err
 	}
 
+	if d.Get("wait_for_bgp_up").(bool) {
+		if err := dxVirtualInterfaceWaitUntilBgpUp(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
 	return resourceAwsDxPrivateVirtualInterfaceRead(d, meta)
 }
 
@@ -192,36 +377,63 @@ func resourceAwsDxPrivateVirtualInterfaceRead(d *schema.ResourceData, meta inter
 		return nil
 	}
 
+	connection := dxVirtualInterfaceConnection(conn, vif)
+
+	// Only hosted virtual interfaces are owned by an account other than the connection owner.
+	if connectionOwnerAccountId := aws.StringValue(connection.OwnerAccount); connectionOwnerAccountId != "" && connectionOwnerAccountId != aws.StringValue(vif.OwnerAccount) {
+		log.Printf("[WARN] Direct Connect virtual interface (%s) is owned by account %s but its connection (%s) is owned by account %s, should it be a hosted virtual interface?", d.Id(), aws.StringValue(vif.OwnerAccount), aws.StringValue(vif.ConnectionId), connectionOwnerAccountId)
+	}
+
 	d.Set("address_family", vif.AddressFamily)
 	d.Set("amazon_address", vif.AmazonAddress)
 	d.Set("amazon_side_asn", strconv.FormatInt(aws.Int64Value(vif.AmazonSideAsn), 10))
-	arn := arn.ARN{
-		Partition: meta.(*AWSClient).partition,
-		Region:    meta.(*AWSClient).region,
-		Service:   "directconnect",
-		AccountID: meta.(*AWSClient).accountid,
-		Resource:  fmt.Sprintf("dxvif/%s", d.Id()),
-	}.String()
+	arn := dxVirtualInterfaceArn(meta.(*AWSClient), meta.(*AWSClient).accountid, d.Id())
 	d.Set("arn", arn)
+	d.Set("partition", meta.(*AWSClient).partition)
 	d.Set("aws_device", vif.AwsDeviceV2)
+	d.Set("aws_logical_device_id", vif.AwsLogicalDeviceId)
 	d.Set("bgp_asn", vif.Asn)
 	d.Set("bgp_auth_key", vif.AuthKey)
+	d.Set("bgp_auth_key_set", aws.StringValue(vif.AuthKey) != "")
+	if err := d.Set("bgp_peer_ids", flattenDxVirtualInterfaceBgpPeerIds(vif)); err != nil {
+		return fmt.Errorf("error setting bgp_peer_ids: %w", err)
+	}
+	if err := d.Set("cloudwatch_dimensions", flattenDxVirtualInterfaceCloudWatchDimensions(vif)); err != nil {
+		return fmt.Errorf("error setting cloudwatch_dimensions: %w", err)
+	}
+	d.Set("connection_encryption_status", connection.PortEncryptionStatus)
 	d.Set("connection_id", vif.ConnectionId)
+	d.Set("connection_owner_account_id", connection.OwnerAccount)
+	d.Set("connection_state", connection.ConnectionState)
+	d.Set("location", connection.Location)
 	d.Set("customer_address", vif.CustomerAddress)
+	d.Set("peer_addresses_consistent", dxPeerAddressesConsistent(aws.StringValue(vif.AmazonAddress), aws.StringValue(vif.CustomerAddress)))
 	d.Set("dx_gateway_id", vif.DirectConnectGatewayId)
 	d.Set("jumbo_frame_capable", vif.JumboFrameCapable)
 	d.Set("mtu", vif.Mtu)
 	d.Set("name", vif.VirtualInterfaceName)
+	d.Set("owner_account_id", vif.OwnerAccount)
+	d.Set("name_prefix", naming.NamePrefixFromName(aws.StringValue(vif.VirtualInterfaceName)))
+	d.Set("state", aws.StringValue(vif.VirtualInterfaceState))
+	d.Set("role", dxVirtualInterfaceRole(vif, meta.(*AWSClient).accountid, false))
+	d.Set("virtual_interface_type", vif.VirtualInterfaceType)
 	d.Set("vlan", vif.Vlan)
 	d.Set("vpn_gateway_id", vif.VirtualGatewayId)
 
+	routerConfig, err := dxVirtualInterfaceRouterConfiguration(conn, d.Id(), d.Get("router_type_identifier").(string))
+	if err != nil {
+		return err
+	}
+	d.Set("router_config", routerConfig)
+
 	tags, err := keyvaluetags.DirectconnectListTags(conn, arn)
 
 	if err != nil {
 		return fmt.Errorf("error listing tags for Direct Connect private virtual interface (%s): %s", arn, err)
 	}
 
-	tags = tags.IgnoreAws().IgnoreConfig(ignoreTagsConfig)
+	tags = dxFilterSystemTags(tags, ignoreTagsConfig).Ignore(keyvaluetags.New(d.Get("ignore_tag_keys").(*schema.Set).List()))
+	tags = dxVirtualInterfaceFlattenDisplayName(d, tags)
 
 	//lintignore:AWSR002
 	if err := d.Set("tags", tags.RemoveDefaultConfig(defaultTagsConfig).Map()); err != nil {
@@ -240,6 +452,10 @@ func resourceAwsDxPrivateVirtualInterfaceUpdate(d *schema.ResourceData, meta int
 		return err
 	}
 
+	if err := dxVirtualInterfaceUpdateDisplayName(d, meta); err != nil {
+		return err
+	}
+
 	if err := dxPrivateVirtualInterfaceWaitUntilAvailable(meta.(*AWSClient).dxconn, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
 		return err
 	}
@@ -266,6 +482,14 @@ func resourceAwsDxPrivateVirtualInterfaceImport(d *schema.ResourceData, meta int
 		return nil, fmt.Errorf("virtual interface (%s) has incorrect type: %s", d.Id(), vifType)
 	}
 
+	d.Set("drain_before_delete", false)
+	d.Set("prevent_active_delete", false)
+	d.Set("skip_delete_wait", false)
+	d.Set("strict_address_family", false)
+	d.Set("wait_for_bgp_up", false)
+	d.Set("wait_for_connection", false)
+	d.Set("warn_if_last_on_connection", false)
+
 	return []*schema.ResourceData{d}, nil
 }
 
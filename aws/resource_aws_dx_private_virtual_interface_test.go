@@ -3,17 +3,313 @@ package aws
 import (
 	"fmt"
 	"os"
+	"reflect"
 	"regexp"
 	"strconv"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/directconnect"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/naming"
 )
 
+func TestDxPrivateVirtualInterfaceRead_tagsDrift(t *testing.T) {
+	var describeTagsCalled bool
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+			}}
+		case *directconnect.DescribeTagsOutput:
+			describeTagsCalled = true
+			// Tags modified outside of Terraform.
+			data.ResourceTags = []*directconnect.ResourceTag{{
+				Tags: []*directconnect.Tag{
+					{Key: aws.String("Key1"), Value: aws.String("Value1b")},
+					{Key: aws.String("Key2"), Value: aws.String("Value2")},
+				},
+			}}
+		}
+	})
+
+	d := resourceAwsDxPrivateVirtualInterface().Data(nil)
+	d.SetId("dxvif-12345678")
+	d.Set("tags", map[string]string{"Key1": "Value1"})
+
+	if err := resourceAwsDxPrivateVirtualInterfaceRead(d, &AWSClient{dxconn: conn}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !describeTagsCalled {
+		t.Error("expected DescribeTags to be called")
+	}
+
+	tags := d.Get("tags").(map[string]interface{})
+	if len(tags) != 2 || tags["Key1"] != "Value1b" || tags["Key2"] != "Value2" {
+		t.Errorf("expected externally modified tags, got %v", tags)
+	}
+}
+
+func TestDxPrivateVirtualInterfaceRead_displayName(t *testing.T) {
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceName:  aws.String("tf-vif"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+			}}
+		case *directconnect.DescribeTagsOutput:
+			data.ResourceTags = []*directconnect.ResourceTag{{
+				Tags: []*directconnect.Tag{
+					{Key: aws.String("Key1"), Value: aws.String("Value1")},
+					{Key: aws.String("Name"), Value: aws.String("Renamed VIF")},
+				},
+			}}
+		}
+	})
+
+	d := resourceAwsDxPrivateVirtualInterface().Data(nil)
+	d.SetId("dxvif-12345678")
+	d.Set("display_name", "Original VIF")
+
+	if err := resourceAwsDxPrivateVirtualInterfaceRead(d, &AWSClient{dxconn: conn}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if v := d.Get("display_name").(string); v != "Renamed VIF" {
+		t.Errorf("expected display_name Renamed VIF, got %s", v)
+	}
+	if v := d.Get("name").(string); v != "tf-vif" {
+		t.Errorf("expected name tf-vif, got %s", v)
+	}
+
+	tags := d.Get("tags").(map[string]interface{})
+	if len(tags) != 1 || tags["Key1"] != "Value1" {
+		t.Errorf("expected tags without the display name tag, got %v", tags)
+	}
+}
+
+func TestDxPrivateVirtualInterfaceRead_ipv6PeerAddresses(t *testing.T) {
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			// AWS normalizes the configured peer addresses.
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				AddressFamily:         aws.String(directconnect.AddressFamilyIpv6),
+				AmazonAddress:         aws.String("2001:db8::1/125"),
+				CustomerAddress:       aws.String("2001:db8::2/125"),
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+			}}
+		case *directconnect.DescribeTagsOutput:
+			data.ResourceTags = []*directconnect.ResourceTag{{}}
+		}
+	})
+
+	d := resourceAwsDxPrivateVirtualInterface().Data(nil)
+	d.SetId("dxvif-12345678")
+	d.Set("address_family", directconnect.AddressFamilyIpv6)
+	d.Set("amazon_address", "2001:0db8:0000::1/125")
+	d.Set("customer_address", "2001:0db8:0000::2/125")
+
+	if err := resourceAwsDxPrivateVirtualInterfaceRead(d, &AWSClient{dxconn: conn}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if v := d.Get("amazon_address").(string); v != "2001:db8::1/125" {
+		t.Errorf("expected amazon_address 2001:db8::1/125, got %s", v)
+	}
+	if v := d.Get("customer_address").(string); v != "2001:db8::2/125" {
+		t.Errorf("expected customer_address 2001:db8::2/125, got %s", v)
+	}
+}
+
+func TestDxPrivateVirtualInterfaceRead_autoAssignedPeerAddresses(t *testing.T) {
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				AddressFamily:         aws.String(directconnect.AddressFamilyIpv4),
+				AmazonAddress:         aws.String("169.254.255.1/30"),
+				CustomerAddress:       aws.String("169.254.255.2/30"),
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+			}}
+		case *directconnect.DescribeTagsOutput:
+			data.ResourceTags = []*directconnect.ResourceTag{{}}
+		}
+	})
+
+	// Neither address is configured.
+	d := resourceAwsDxPrivateVirtualInterface().Data(nil)
+	d.SetId("dxvif-12345678")
+
+	if err := resourceAwsDxPrivateVirtualInterfaceRead(d, &AWSClient{dxconn: conn}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for k, expected := range map[string]string{"amazon_address": "169.254.255.1/30", "customer_address": "169.254.255.2/30"} {
+		if got := d.Get(k).(string); got != expected {
+			t.Errorf("got %s %s, expected %s", k, got, expected)
+		}
+	}
+}
+
+func TestDxPrivateVirtualInterfaceRead_connectionOwnerAccountId(t *testing.T) {
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.Connections:
+			data.Connections = []*directconnect.Connection{{
+				ConnectionId: aws.String("dxcon-12345678"),
+				OwnerAccount: aws.String("111111111111"),
+			}}
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				ConnectionId:          aws.String("dxcon-12345678"),
+				OwnerAccount:          aws.String("222222222222"),
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+			}}
+		case *directconnect.DescribeTagsOutput:
+			data.ResourceTags = []*directconnect.ResourceTag{{}}
+		}
+	})
+
+	d := resourceAwsDxPrivateVirtualInterface().Data(nil)
+	d.SetId("dxvif-12345678")
+
+	if err := resourceAwsDxPrivateVirtualInterfaceRead(d, &AWSClient{dxconn: conn}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for k, expected := range map[string]string{"connection_owner_account_id": "111111111111", "owner_account_id": "222222222222"} {
+		if got := d.Get(k).(string); got != expected {
+			t.Errorf("got %s %s, expected %s", k, got, expected)
+		}
+	}
+}
+
+func TestDxPrivateVirtualInterfaceRead_connectionState(t *testing.T) {
+	describeConnectionsCalls := 0
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.Connections:
+			describeConnectionsCalls++
+			data.Connections = []*directconnect.Connection{{
+				ConnectionId:    aws.String("dxcon-12345678"),
+				ConnectionState: aws.String(directconnect.ConnectionStateDown),
+				Location:        aws.String("EqDC2"),
+			}}
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				ConnectionId:          aws.String("dxcon-12345678"),
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+			}}
+		case *directconnect.DescribeTagsOutput:
+			data.ResourceTags = []*directconnect.ResourceTag{{}}
+		}
+	})
+
+	d := resourceAwsDxPrivateVirtualInterface().Data(nil)
+	d.SetId("dxvif-12345678")
+
+	if err := resourceAwsDxPrivateVirtualInterfaceRead(d, &AWSClient{dxconn: conn}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, expected := d.Get("connection_state").(string), directconnect.ConnectionStateDown; got != expected {
+		t.Errorf("got connection_state %s, expected %s", got, expected)
+	}
+	if got, expected := d.Get("location").(string), "EqDC2"; got != expected {
+		t.Errorf("got location %s, expected %s", got, expected)
+	}
+
+	// All connection attributes are read from a single cached connection lookup.
+	if describeConnectionsCalls != 1 {
+		t.Errorf("expected 1 DescribeConnections call, got %d", describeConnectionsCalls)
+	}
+}
+
+func TestDxPrivateVirtualInterfaceRead_routerConfig(t *testing.T) {
+	var routerTypeIdentifiers []string
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeRouterConfigurationOutput:
+			routerTypeIdentifiers = append(routerTypeIdentifiers, aws.StringValue(r.Params.(*directconnect.DescribeRouterConfigurationInput).RouterTypeIdentifier))
+			data.CustomerRouterConfig = aws.String("router bgp 65000")
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+			}}
+		case *directconnect.DescribeTagsOutput:
+			data.ResourceTags = []*directconnect.ResourceTag{{}}
+		}
+	})
+	meta := &AWSClient{dxconn: conn}
+
+	d := resourceAwsDxPrivateVirtualInterface().Data(nil)
+	d.SetId("dxvif-12345678")
+
+	if err := resourceAwsDxPrivateVirtualInterfaceRead(d, meta); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := d.Get("router_config").(string); got != "" {
+		t.Errorf("got router_config %q without router_type_identifier, expected none", got)
+	}
+	if len(routerTypeIdentifiers) != 0 {
+		t.Errorf("expected no DescribeRouterConfiguration calls without router_type_identifier, got %d", len(routerTypeIdentifiers))
+	}
+
+	d.Set("router_type_identifier", "CiscoSystemsInc-2900SeriesRouters-IOS124")
+
+	if err := resourceAwsDxPrivateVirtualInterfaceRead(d, meta); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, expected := d.Get("router_config").(string), "router bgp 65000"; got != expected {
+		t.Errorf("got router_config %q, expected %q", got, expected)
+	}
+	if expected := []string{"CiscoSystemsInc-2900SeriesRouters-IOS124"}; !reflect.DeepEqual(routerTypeIdentifiers, expected) {
+		t.Errorf("expected DescribeRouterConfiguration calls for %v, got %v", expected, routerTypeIdentifiers)
+	}
+}
+
+func TestDxPrivateVirtualInterfaceRead_govCloudArn(t *testing.T) {
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				VirtualInterfaceId:    aws.String("dxvif-12345678"),
+				VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+			}}
+		case *directconnect.DescribeTagsOutput:
+			data.ResourceTags = []*directconnect.ResourceTag{{}}
+		}
+	})
+
+	d := resourceAwsDxPrivateVirtualInterface().Data(nil)
+	d.SetId("dxvif-12345678")
+
+	client := &AWSClient{accountid: "123456789012", dxconn: conn, partition: "aws-us-gov", region: "us-gov-west-1"}
+	if err := resourceAwsDxPrivateVirtualInterfaceRead(d, client); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, expected := d.Get("arn").(string), "arn:aws-us-gov:directconnect:us-gov-west-1:123456789012:dxvif/dxvif-12345678"; got != expected {
+		t.Errorf("got arn %s, expected %s", got, expected)
+	}
+}
+
 func TestAccAwsDxPrivateVirtualInterface_basic(t *testing.T) {
 	key := "DX_CONNECTION_ID"
 	connectionId := os.Getenv(key)
@@ -45,12 +341,16 @@ func TestAccAwsDxPrivateVirtualInterface_basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "aws_device"),
 					resource.TestCheckResourceAttr(resourceName, "bgp_asn", strconv.Itoa(bgpAsn)),
 					resource.TestCheckResourceAttrSet(resourceName, "bgp_auth_key"),
+					resource.TestCheckResourceAttr(resourceName, "bgp_auth_key_set", "true"),
+					resource.TestCheckResourceAttr(resourceName, "bgp_peer_ids.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "connection_id", connectionId),
 					resource.TestCheckResourceAttrSet(resourceName, "customer_address"),
 					resource.TestCheckResourceAttr(resourceName, "jumbo_frame_capable", "true"),
 					resource.TestCheckResourceAttr(resourceName, "mtu", "1500"),
 					resource.TestCheckResourceAttr(resourceName, "name", rName),
 					resource.TestCheckResourceAttr(resourceName, "tags.%", "0"),
+					resource.TestCheckResourceAttr(resourceName, "peer_addresses_consistent", "true"),
+					resource.TestCheckResourceAttr(resourceName, "virtual_interface_type", "private"),
 					resource.TestCheckResourceAttr(resourceName, "vlan", strconv.Itoa(vlan)),
 					resource.TestCheckResourceAttrPair(resourceName, "vpn_gateway_id", vpnGatewayResourceName, "id"),
 				),
@@ -78,9 +378,10 @@ func TestAccAwsDxPrivateVirtualInterface_basic(t *testing.T) {
 			},
 			// Test import.
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"created_at"},
 			},
 		},
 	})
@@ -156,9 +457,56 @@ func TestAccAwsDxPrivateVirtualInterface_Tags(t *testing.T) {
 			},
 			// Test import.
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"created_at"},
+			},
+		},
+	})
+}
+
+func TestAccAwsDxPrivateVirtualInterface_IgnoreTagKeys(t *testing.T) {
+	key := "DX_CONNECTION_ID"
+	connectionId := os.Getenv(key)
+	if connectionId == "" {
+		t.Skipf("Environment variable %s is not set", key)
+	}
+
+	var vif directconnect.VirtualInterface
+	resourceName := "aws_dx_private_virtual_interface.test"
+	rName := fmt.Sprintf("tf-testacc-private-vif-%s", acctest.RandString(9))
+	bgpAsn := acctest.RandIntRange(64512, 65534)
+	vlan := acctest.RandIntRange(2049, 4094)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		ErrorCheck:   testAccErrorCheck(t, directconnect.EndpointsID),
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDxPrivateVirtualInterfaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDxPrivateVirtualInterfaceConfig_tags(connectionId, rName, bgpAsn, vlan),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDxPrivateVirtualInterfaceExists(resourceName, &vif),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "3"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Key1", "Value1"),
+				),
+			},
+			{
+				// Handing Key1 over to management outside of Terraform leaves the tag in place.
+				Config: testAccDxPrivateVirtualInterfaceConfig_ignoreTagKeys(connectionId, rName, bgpAsn, vlan),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDxPrivateVirtualInterfaceExists(resourceName, &vif),
+					resource.TestCheckResourceAttr(resourceName, "ignore_tag_keys.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "2"),
+					resource.TestCheckNoResourceAttr(resourceName, "tags.Key1"),
+					testAccCheckDxVirtualInterfaceTag(&vif, "Key1", "Value1"),
+				),
+			},
+			{
+				Config:      testAccDxPrivateVirtualInterfaceConfig_ignoreTagKeysConflict(connectionId, rName, bgpAsn, vlan),
+				ExpectError: regexp.MustCompile(`tags Key1 are in both 'tags'`),
 			},
 		},
 	})
@@ -208,9 +556,48 @@ func TestAccAwsDxPrivateVirtualInterface_DxGateway(t *testing.T) {
 			},
 			// Test import.
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"created_at"},
+			},
+		},
+	})
+}
+
+func TestAccAwsDxPrivateVirtualInterface_NamePrefix(t *testing.T) {
+	key := "DX_CONNECTION_ID"
+	connectionId := os.Getenv(key)
+	if connectionId == "" {
+		t.Skipf("Environment variable %s is not set", key)
+	}
+
+	var vif directconnect.VirtualInterface
+	resourceName := "aws_dx_private_virtual_interface.test"
+	rName := fmt.Sprintf("tf-testacc-private-vif-%s", acctest.RandString(9))
+	bgpAsn := acctest.RandIntRange(64512, 65534)
+	vlan := acctest.RandIntRange(2049, 4094)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		ErrorCheck:   testAccErrorCheck(t, directconnect.EndpointsID),
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDxPrivateVirtualInterfaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDxPrivateVirtualInterfaceConfig_namePrefix(connectionId, rName, "tf-acc-test-prefix-", bgpAsn, vlan),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDxPrivateVirtualInterfaceExists(resourceName, &vif),
+					naming.TestCheckResourceAttrNameFromPrefix(resourceName, "name", "tf-acc-test-prefix-"),
+					resource.TestCheckResourceAttr(resourceName, "name_prefix", "tf-acc-test-prefix-"),
+				),
+			},
+			// Test import.
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"created_at"},
 			},
 		},
 	})
@@ -246,6 +633,19 @@ resource "aws_dx_private_virtual_interface" "test" {
 `, cid, rName, bgpAsn, vlan)
 }
 
+func testAccDxPrivateVirtualInterfaceConfig_namePrefix(cid, rName, namePrefix string, bgpAsn, vlan int) string {
+	return testAccDxPrivateVirtualInterfaceConfig_vpnGateway(rName) + fmt.Sprintf(`
+resource "aws_dx_private_virtual_interface" "test" {
+  address_family = "ipv4"
+  bgp_asn        = %[3]d
+  connection_id  = %[1]q
+  name_prefix    = %[2]q
+  vlan           = %[4]d
+  vpn_gateway_id = aws_vpn_gateway.test.id
+}
+`, cid, namePrefix, bgpAsn, vlan)
+}
+
 func testAccDxPrivateVirtualInterfaceConfig_updated(cid, rName string, bgpAsn, vlan int) string {
 	return testAccDxPrivateVirtualInterfaceConfig_vpnGateway(rName) + fmt.Sprintf(`
 resource "aws_dx_private_virtual_interface" "test" {
@@ -298,6 +698,45 @@ resource "aws_dx_private_virtual_interface" "test" {
 `, cid, rName, bgpAsn, vlan)
 }
 
+func testAccDxPrivateVirtualInterfaceConfig_ignoreTagKeys(cid, rName string, bgpAsn, vlan int) string {
+	return testAccDxPrivateVirtualInterfaceConfig_vpnGateway(rName) + fmt.Sprintf(`
+resource "aws_dx_private_virtual_interface" "test" {
+  address_family  = "ipv4"
+  bgp_asn         = %[3]d
+  connection_id   = %[1]q
+  ignore_tag_keys = ["Key1"]
+  name            = %[2]q
+  vlan            = %[4]d
+  vpn_gateway_id  = aws_vpn_gateway.test.id
+
+  tags = {
+    Name = %[2]q
+    Key2 = "Value2a"
+  }
+}
+`, cid, rName, bgpAsn, vlan)
+}
+
+func testAccDxPrivateVirtualInterfaceConfig_ignoreTagKeysConflict(cid, rName string, bgpAsn, vlan int) string {
+	return testAccDxPrivateVirtualInterfaceConfig_vpnGateway(rName) + fmt.Sprintf(`
+resource "aws_dx_private_virtual_interface" "test" {
+  address_family  = "ipv4"
+  bgp_asn         = %[3]d
+  connection_id   = %[1]q
+  ignore_tag_keys = ["Key1"]
+  name            = %[2]q
+  vlan            = %[4]d
+  vpn_gateway_id  = aws_vpn_gateway.test.id
+
+  tags = {
+    Name = %[2]q
+    Key1 = "Value1"
+    Key2 = "Value2a"
+  }
+}
+`, cid, rName, bgpAsn, vlan)
+}
+
 func testAccDxPrivateVirtualInterfaceConfig_dxGateway(cid, rName string, amzAsn, bgpAsn, vlan int) string {
 	return fmt.Sprintf(`
 resource "aws_dx_gateway" "test" {
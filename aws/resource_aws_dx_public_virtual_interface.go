@@ -8,12 +8,12 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/directconnect"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/terraform-providers/terraform-provider-aws/aws/internal/keyvaluetags"
+	"github.com/terraform-providers/terraform-provider-aws/aws/internal/naming"
 )
 
 func resourceAwsDxPublicVirtualInterface() *schema.Resource {
@@ -27,7 +27,13 @@ func resourceAwsDxPublicVirtualInterface() *schema.Resource {
 		},
 		CustomizeDiff: customdiff.Sequence(
 			resourceAwsDxPublicVirtualInterfaceCustomizeDiff,
+			dxVirtualInterfaceCustomizeDiff,
+			dxVirtualInterfaceRouteFilterPrefixesCustomizeDiff,
+			dxVirtualInterfaceDisplayNameCustomizeDiff,
+			dxVirtualInterfaceVlanCustomizeDiff,
 			SetTagsDiff,
+			dxVirtualInterfaceIgnoreTagKeysCustomizeDiff,
+			dxVirtualInterfaceRequiredTagsCustomizeDiff,
 		),
 
 		Schema: map[string]*schema.Schema{
@@ -58,48 +64,216 @@ func resourceAwsDxPublicVirtualInterface() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"aws_logical_device_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"bgp_asn": {
-				Type:     schema.TypeInt,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateDxBgpAsn,
 			},
 			"bgp_auth_key": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Computed:  true,
+				ForceNew:  true,
+				Sensitive: true,
+			},
+			"bgp_auth_key_charset": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validateDxBgpAuthKeyCharset,
+				ConflictsWith: []string{"bgp_auth_key"},
+				RequiredWith:  []string{"bgp_auth_key_length"},
+			},
+			"bgp_auth_key_length": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validation.IntBetween(dxBgpAuthKeyMinLength, dxBgpAuthKeyMaxLength),
+				ConflictsWith: []string{"bgp_auth_key"},
+			},
+			"bgp_auth_key_set": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"bgp_peer_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"cloudwatch_dimensions": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"connection_encryption_status": {
 				Type:     schema.TypeString,
-				Optional: true,
 				Computed: true,
-				ForceNew: true,
 			},
 			"connection_id": {
 				Type:     schema.TypeString,
 				Required: true,
 				ForceNew: true,
 			},
+			"connection_owner_account_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"connection_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"customer_address": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
 				ForceNew: true,
 			},
+			"display_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"drain_before_delete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"ignore_unmanaged_route_filter_prefixes": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"ignore_tag_keys": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"location": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"name_prefix"},
+			},
+			"name_prefix": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"name"},
+				ValidateFunc:  validateDxVirtualInterfaceNamePrefix,
+			},
+			"ordered_route_filter_prefixes": {
+				Type:             schema.TypeList,
+				Optional:         true,
+				ForceNew:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				MinItems:         1,
+				ExactlyOneOf:     []string{"ordered_route_filter_prefixes", "route_filter_prefixes"},
+				DiffSuppressFunc: dxVirtualInterfaceSuppressRouteFilterPrefixesOrderDiff,
+			},
+			"owner_account_id": {
 				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Computed: true,
 			},
-			"route_filter_prefixes": {
+			"partition": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"peer_addresses_consistent": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"prevent_active_delete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"required_tag_keys": {
 				Type:     schema.TypeSet,
-				Required: true,
-				ForceNew: true,
+				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
-				MinItems: 1,
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"route_filter_prefixes": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				MinItems:     1,
+				ExactlyOneOf: []string{"ordered_route_filter_prefixes", "route_filter_prefixes"},
+			},
+			"router_config": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"router_type_identifier": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"skip_delete_wait": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"sorted_route_filter_prefixes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"strict_address_family": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
 			},
 			"tags":     tagsSchema(),
 			"tags_all": tagsSchemaComputed(),
+			"virtual_interface_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"vlan": {
 				Type:         schema.TypeInt,
 				Required:     true,
 				ForceNew:     true,
 				ValidateFunc: validation.IntBetween(1, 4094),
 			},
+			"wait_for_bgp_up": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"wait_for_connection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"warn_if_last_on_connection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 
 		Timeouts: &schema.ResourceTimeout{
@@ -113,21 +287,26 @@ func resourceAwsDxPublicVirtualInterfaceCreate(d *schema.ResourceData, meta inte
 	conn := meta.(*AWSClient).dxconn
 	defaultTagsConfig := meta.(*AWSClient).DefaultTagsConfig
 	tags := defaultTagsConfig.MergeTags(keyvaluetags.New(d.Get("tags").(map[string]interface{})))
+	tags = tags.Merge(dxVirtualInterfaceDisplayNameTags(d.Get("display_name").(string)))
 
 	req := &directconnect.CreatePublicVirtualInterfaceInput{
 		ConnectionId: aws.String(d.Get("connection_id").(string)),
 		NewPublicVirtualInterface: &directconnect.NewPublicVirtualInterface{
 			AddressFamily:        aws.String(d.Get("address_family").(string)),
 			Asn:                  aws.Int64(int64(d.Get("bgp_asn").(int))),
-			VirtualInterfaceName: aws.String(d.Get("name").(string)),
+			VirtualInterfaceName: aws.String(naming.Generate(d.Get("name").(string), d.Get("name_prefix").(string))),
 			Vlan:                 aws.Int64(int64(d.Get("vlan").(int))),
 		},
 	}
 	if v, ok := d.GetOk("amazon_address"); ok {
 		req.NewPublicVirtualInterface.AmazonAddress = aws.String(v.(string))
 	}
-	if v, ok := d.GetOk("bgp_auth_key"); ok {
-		req.NewPublicVirtualInterface.AuthKey = aws.String(v.(string))
+	authKey, err := dxVirtualInterfaceBgpAuthKey(d)
+	if err != nil {
+		return err
+	}
+	if authKey != "" {
+		req.NewPublicVirtualInterface.AuthKey = aws.String(authKey)
 	}
 	if v, ok := d.GetOk("customer_address"); ok {
 		req.NewPublicVirtualInterface.CustomerAddress = aws.String(v.(string))
@@ -135,10 +314,23 @@ func resourceAwsDxPublicVirtualInterfaceCreate(d *schema.ResourceData, meta inte
 	if v, ok := d.GetOk("route_filter_prefixes"); ok {
 		req.NewPublicVirtualInterface.RouteFilterPrefixes = expandDxRouteFilterPrefixes(v.(*schema.Set))
 	}
+	if v, ok := d.GetOk("ordered_route_filter_prefixes"); ok {
+		req.NewPublicVirtualInterface.RouteFilterPrefixes = expandDxRouteFilterPrefixes(schema.NewSet(schema.HashString, v.([]interface{})))
+	}
 	if len(tags) > 0 {
 		req.NewPublicVirtualInterface.Tags = tags.IgnoreAws().DirectconnectTags()
 	}
 
+	if d.Get("wait_for_connection").(bool) {
+		if err := dxVirtualInterfaceWaitUntilConnectionAvailable(conn, d.Get("connection_id").(string), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
+	if err := dxVirtualInterfaceCheckVlanAvailable(conn, d.Get("connection_id").(string), d.Get("vlan").(int), ""); err != nil {
+		return err
+	}
+
 	log.Printf("[DEBUG] Creating Direct Connect public virtual interface: %s", req)
 	resp, err := conn.CreatePublicVirtualInterface(req)
 	if err != nil {
@@ -146,11 +338,18 @@ func resourceAwsDxPublicVirtualInterfaceCreate(d *schema.ResourceData, meta inte
 	}
 
 	d.SetId(aws.StringValue(resp.VirtualInterfaceId))
+	d.Set("created_at", time.Now().UTC().Format(time.RFC3339))
 
 	if err := dxPublicVirtualInterfaceWaitUntilAvailable(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
 		return err
 	}
 
+	if d.Get("wait_for_bgp_up").(bool) {
+		if err := dxVirtualInterfaceWaitUntilBgpUp(conn, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
 	return resourceAwsDxPublicVirtualInterfaceRead(d, meta)
 }
 
@@ -169,35 +368,79 @@ func resourceAwsDxPublicVirtualInterfaceRead(d *schema.ResourceData, meta interf
 		return nil
 	}
 
+	connection := dxVirtualInterfaceConnection(conn, vif)
+
+	// Only hosted virtual interfaces are owned by an account other than the connection owner.
+	if connectionOwnerAccountId := aws.StringValue(connection.OwnerAccount); connectionOwnerAccountId != "" && connectionOwnerAccountId != aws.StringValue(vif.OwnerAccount) {
+		log.Printf("[WARN] Direct Connect virtual interface (%s) is owned by account %s but its connection (%s) is owned by account %s, should it be a hosted virtual interface?", d.Id(), aws.StringValue(vif.OwnerAccount), aws.StringValue(vif.ConnectionId), connectionOwnerAccountId)
+	}
+
 	d.Set("address_family", vif.AddressFamily)
 	d.Set("amazon_address", vif.AmazonAddress)
 	d.Set("amazon_side_asn", strconv.FormatInt(aws.Int64Value(vif.AmazonSideAsn), 10))
-	arn := arn.ARN{
-		Partition: meta.(*AWSClient).partition,
-		Region:    meta.(*AWSClient).region,
-		Service:   "directconnect",
-		AccountID: meta.(*AWSClient).accountid,
-		Resource:  fmt.Sprintf("dxvif/%s", d.Id()),
-	}.String()
+	arn := dxVirtualInterfaceArn(meta.(*AWSClient), meta.(*AWSClient).accountid, d.Id())
 	d.Set("arn", arn)
+	d.Set("partition", meta.(*AWSClient).partition)
 	d.Set("aws_device", vif.AwsDeviceV2)
+	d.Set("aws_logical_device_id", vif.AwsLogicalDeviceId)
 	d.Set("bgp_asn", vif.Asn)
 	d.Set("bgp_auth_key", vif.AuthKey)
+	d.Set("bgp_auth_key_set", aws.StringValue(vif.AuthKey) != "")
+	if err := d.Set("bgp_peer_ids", flattenDxVirtualInterfaceBgpPeerIds(vif)); err != nil {
+		return fmt.Errorf("error setting bgp_peer_ids: %w", err)
+	}
 	d.Set("customer_address", vif.CustomerAddress)
+	d.Set("peer_addresses_consistent", dxPeerAddressesConsistent(aws.StringValue(vif.AmazonAddress), aws.StringValue(vif.CustomerAddress)))
+	if err := d.Set("cloudwatch_dimensions", flattenDxVirtualInterfaceCloudWatchDimensions(vif)); err != nil {
+		return fmt.Errorf("error setting cloudwatch_dimensions: %w", err)
+	}
+	d.Set("connection_encryption_status", connection.PortEncryptionStatus)
 	d.Set("connection_id", vif.ConnectionId)
+	d.Set("connection_owner_account_id", connection.OwnerAccount)
+	d.Set("connection_state", connection.ConnectionState)
+	d.Set("location", connection.Location)
 	d.Set("name", vif.VirtualInterfaceName)
-	if err := d.Set("route_filter_prefixes", flattenDxRouteFilterPrefixes(vif.RouteFilterPrefixes)); err != nil {
+	d.Set("owner_account_id", vif.OwnerAccount)
+	d.Set("name_prefix", naming.NamePrefixFromName(aws.StringValue(vif.VirtualInterfaceName)))
+	routeFilterPrefixes := flattenDxRouteFilterPrefixes(vif.RouteFilterPrefixes)
+	if d.Get("ignore_unmanaged_route_filter_prefixes").(bool) {
+		// Leave out any prefixes added outside of Terraform, e.g. by AWS.
+		managedRouteFilterPrefixes := d.Get("route_filter_prefixes").(*schema.Set)
+		if v := d.Get("ordered_route_filter_prefixes").([]interface{}); len(v) > 0 {
+			managedRouteFilterPrefixes = schema.NewSet(schema.HashString, v)
+		}
+		routeFilterPrefixes = routeFilterPrefixes.Intersection(managedRouteFilterPrefixes)
+	}
+	if err := d.Set("route_filter_prefixes", routeFilterPrefixes); err != nil {
 		return fmt.Errorf("error setting route_filter_prefixes: %s", err)
 	}
+	if v := d.Get("ordered_route_filter_prefixes").([]interface{}); len(v) > 0 {
+		if err := d.Set("ordered_route_filter_prefixes", dxOrderedRouteFilterPrefixes(v, routeFilterPrefixes)); err != nil {
+			return fmt.Errorf("error setting ordered_route_filter_prefixes: %w", err)
+		}
+	}
+	if err := d.Set("sorted_route_filter_prefixes", dxSortCidrs(aws.StringValueSlice(expandStringSet(routeFilterPrefixes)))); err != nil {
+		return fmt.Errorf("error setting sorted_route_filter_prefixes: %w", err)
+	}
+	d.Set("state", aws.StringValue(vif.VirtualInterfaceState))
+	d.Set("role", dxVirtualInterfaceRole(vif, meta.(*AWSClient).accountid, false))
+	d.Set("virtual_interface_type", vif.VirtualInterfaceType)
 	d.Set("vlan", vif.Vlan)
 
+	routerConfig, err := dxVirtualInterfaceRouterConfiguration(conn, d.Id(), d.Get("router_type_identifier").(string))
+	if err != nil {
+		return err
+	}
+	d.Set("router_config", routerConfig)
+
 	tags, err := keyvaluetags.DirectconnectListTags(conn, arn)
 
 	if err != nil {
 		return fmt.Errorf("error listing tags for Direct Connect public virtual interface (%s): %s", arn, err)
 	}
 
-	tags = tags.IgnoreAws().IgnoreConfig(ignoreTagsConfig)
+	tags = dxFilterSystemTags(tags, ignoreTagsConfig).Ignore(keyvaluetags.New(d.Get("ignore_tag_keys").(*schema.Set).List()))
+	tags = dxVirtualInterfaceFlattenDisplayName(d, tags)
 
 	//lintignore:AWSR002
 	if err := d.Set("tags", tags.RemoveDefaultConfig(defaultTagsConfig).Map()); err != nil {
@@ -216,6 +459,10 @@ func resourceAwsDxPublicVirtualInterfaceUpdate(d *schema.ResourceData, meta inte
 		return err
 	}
 
+	if err := dxVirtualInterfaceUpdateDisplayName(d, meta); err != nil {
+		return err
+	}
+
 	return resourceAwsDxPublicVirtualInterfaceRead(d, meta)
 }
 
@@ -238,6 +485,15 @@ func resourceAwsDxPublicVirtualInterfaceImport(d *schema.ResourceData, meta inte
 		return nil, fmt.Errorf("virtual interface (%s) has incorrect type: %s", d.Id(), vifType)
 	}
 
+	d.Set("ignore_unmanaged_route_filter_prefixes", false)
+	d.Set("drain_before_delete", false)
+	d.Set("prevent_active_delete", false)
+	d.Set("skip_delete_wait", false)
+	d.Set("strict_address_family", false)
+	d.Set("wait_for_bgp_up", false)
+	d.Set("wait_for_connection", false)
+	d.Set("warn_if_last_on_connection", false)
+
 	return []*schema.ResourceData{d}, nil
 }
 
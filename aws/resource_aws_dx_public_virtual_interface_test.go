@@ -8,12 +8,54 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/directconnect"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
+func TestDxPublicVirtualInterfaceRead_ignoreUnmanagedRouteFilterPrefixes(t *testing.T) {
+	testCases := []struct {
+		ignoreUnmanaged bool
+		expected        int
+	}{
+		{ignoreUnmanaged: false, expected: 2},
+		{ignoreUnmanaged: true, expected: 1},
+	}
+
+	for _, testCase := range testCases {
+		conn := testDxConnWithStub(t, func(r *request.Request) {
+			switch data := r.Data.(type) {
+			case *directconnect.DescribeVirtualInterfacesOutput:
+				data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+					RouteFilterPrefixes: []*directconnect.RouteFilterPrefix{
+						{Cidr: aws.String("210.52.109.0/24")},
+						{Cidr: aws.String("175.45.176.0/22")},
+					},
+					VirtualInterfaceId:    aws.String("dxvif-12345678"),
+					VirtualInterfaceState: aws.String(directconnect.VirtualInterfaceStateAvailable),
+				}}
+			case *directconnect.DescribeTagsOutput:
+				data.ResourceTags = []*directconnect.ResourceTag{{}}
+			}
+		})
+
+		d := resourceAwsDxPublicVirtualInterface().Data(nil)
+		d.SetId("dxvif-12345678")
+		d.Set("ignore_unmanaged_route_filter_prefixes", testCase.ignoreUnmanaged)
+		d.Set("route_filter_prefixes", []string{"210.52.109.0/24"})
+
+		if err := resourceAwsDxPublicVirtualInterfaceRead(d, &AWSClient{dxconn: conn}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if got := d.Get("route_filter_prefixes.#").(int); got != testCase.expected {
+			t.Errorf("ignore_unmanaged_route_filter_prefixes = %t: expected %d route filter prefixes, got %d", testCase.ignoreUnmanaged, testCase.expected, got)
+		}
+	}
+}
+
 func TestAccAwsDxPublicVirtualInterface_basic(t *testing.T) {
 	key := "DX_CONNECTION_ID"
 	connectionId := os.Getenv(key)
@@ -57,14 +99,16 @@ func TestAccAwsDxPublicVirtualInterface_basic(t *testing.T) {
 					resource.TestCheckTypeSetElemAttr(resourceName, "route_filter_prefixes.*", "210.52.109.0/24"),
 					resource.TestCheckTypeSetElemAttr(resourceName, "route_filter_prefixes.*", "175.45.176.0/22"),
 					resource.TestCheckResourceAttr(resourceName, "tags.%", "0"),
+					resource.TestCheckResourceAttr(resourceName, "virtual_interface_type", "public"),
 					resource.TestCheckResourceAttr(resourceName, "vlan", strconv.Itoa(vlan)),
 				),
 			},
 			// Test import.
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"created_at"},
 			},
 		},
 	})
@@ -141,9 +185,50 @@ func TestAccAwsDxPublicVirtualInterface_Tags(t *testing.T) {
 			},
 			// Test import.
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"created_at"},
+			},
+		},
+	})
+}
+
+func TestAccAwsDxPublicVirtualInterface_OrderedRouteFilterPrefixes(t *testing.T) {
+	key := "DX_CONNECTION_ID"
+	connectionId := os.Getenv(key)
+	if connectionId == "" {
+		t.Skipf("Environment variable %s is not set", key)
+	}
+
+	var vif directconnect.VirtualInterface
+	resourceName := "aws_dx_public_virtual_interface.test"
+	rName := fmt.Sprintf("tf-testacc-public-vif-%s", acctest.RandString(10))
+	amazonAddress := "175.45.176.3/28"
+	customerAddress := "175.45.176.4/28"
+	bgpAsn := acctest.RandIntRange(64512, 65534)
+	vlan := acctest.RandIntRange(2049, 4094)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		ErrorCheck:   testAccErrorCheck(t, directconnect.EndpointsID),
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAwsDxPublicVirtualInterfaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDxPublicVirtualInterfaceConfig_orderedRouteFilterPrefixes(connectionId, rName, amazonAddress, customerAddress, `"210.52.109.0/24", "175.45.176.0/22"`, bgpAsn, vlan),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAwsDxPublicVirtualInterfaceExists(resourceName, &vif),
+					resource.TestCheckResourceAttr(resourceName, "ordered_route_filter_prefixes.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "ordered_route_filter_prefixes.0", "210.52.109.0/24"),
+					resource.TestCheckResourceAttr(resourceName, "ordered_route_filter_prefixes.1", "175.45.176.0/22"),
+					resource.TestCheckResourceAttr(resourceName, "route_filter_prefixes.#", "2"),
+				),
+			},
+			{
+				// Reordering the prefixes neither recreates the virtual interface nor causes a difference.
+				Config:   testAccDxPublicVirtualInterfaceConfig_orderedRouteFilterPrefixes(connectionId, rName, amazonAddress, customerAddress, `"175.45.176.0/22", "210.52.109.0/24"`, bgpAsn, vlan),
+				PlanOnly: true,
 			},
 		},
 	})
@@ -225,3 +310,19 @@ resource "aws_dx_public_virtual_interface" "test" {
 }
 `, cid, rName, amzAddr, custAddr, bgpAsn, vlan)
 }
+
+func testAccDxPublicVirtualInterfaceConfig_orderedRouteFilterPrefixes(cid, rName, amzAddr, custAddr, prefixes string, bgpAsn, vlan int) string {
+	return fmt.Sprintf(`
+resource "aws_dx_public_virtual_interface" "test" {
+  address_family   = "ipv4"
+  amazon_address   = %[3]q
+  bgp_asn          = %[6]d
+  connection_id    = %[1]q
+  customer_address = %[4]q
+  name             = %[2]q
+  vlan             = %[7]d
+
+  ordered_route_filter_prefixes = [%[5]s]
+}
+`, cid, rName, amzAddr, custAddr, prefixes, bgpAsn, vlan)
+}
@@ -8,12 +8,67 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/directconnect"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
+func TestDxTransitVirtualInterfaceRead_amazonSideAsnFromGateway(t *testing.T) {
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		switch data := r.Data.(type) {
+		case *directconnect.DescribeVirtualInterfacesOutput:
+			data.VirtualInterfaces = []*directconnect.VirtualInterface{{
+				ConnectionId:           aws.String("dxcon-12345678"),
+				DirectConnectGatewayId: aws.String("dxgw-12345678"),
+				VirtualInterfaceId:     aws.String("dxvif-12345678"),
+				VirtualInterfaceState:  aws.String(directconnect.VirtualInterfaceStateAvailable),
+				VirtualInterfaceType:   aws.String("transit"),
+			}}
+		case *directconnect.DescribeDirectConnectGatewaysOutput:
+			data.DirectConnectGateways = []*directconnect.Gateway{{
+				AmazonSideAsn:             aws.Int64(64512),
+				DirectConnectGatewayId:    aws.String("dxgw-12345678"),
+				DirectConnectGatewayState: aws.String(directconnect.GatewayStateAvailable),
+			}}
+		case *directconnect.DescribeTagsOutput:
+			data.ResourceTags = []*directconnect.ResourceTag{{}}
+		}
+	})
+
+	d := resourceAwsDxTransitVirtualInterface().Data(nil)
+	d.SetId("dxvif-12345678")
+
+	if err := resourceAwsDxTransitVirtualInterfaceRead(d, &AWSClient{dxconn: conn}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, expected := d.Get("amazon_side_asn").(string), "64512"; got != expected {
+		t.Errorf("got amazon_side_asn %s, expected %s", got, expected)
+	}
+}
+
+func TestDxTransitVirtualInterfaceAmazonSideAsn(t *testing.T) {
+	conn := testDxConnWithStub(t, func(r *request.Request) {
+		t.Errorf("unexpected %s call", r.Operation.Name)
+	})
+
+	vif := &directconnect.VirtualInterface{
+		AmazonSideAsn:          aws.Int64(65000),
+		DirectConnectGatewayId: aws.String("dxgw-12345678"),
+	}
+
+	// The gateway is not read if the virtual interface has an Amazon side ASN.
+	if got, expected := dxTransitVirtualInterfaceAmazonSideAsn(conn, vif), int64(65000); got != expected {
+		t.Errorf("got %d, expected %d", got, expected)
+	}
+
+	if got, expected := dxTransitVirtualInterfaceAmazonSideAsn(conn, &directconnect.VirtualInterface{}), int64(0); got != expected {
+		t.Errorf("got %d, expected %d", got, expected)
+	}
+}
+
 func TestAccAwsDxTransitVirtualInterface_serial(t *testing.T) {
 	testCases := map[string]func(t *testing.T){
 		"basic": testAccAwsDxTransitVirtualInterface_basic,
@@ -93,9 +148,10 @@ func testAccAwsDxTransitVirtualInterface_basic(t *testing.T) {
 			},
 			// Test import.
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"created_at"},
 			},
 		},
 	})
@@ -172,9 +228,10 @@ func testAccAwsDxTransitVirtualInterface_Tags(t *testing.T) {
 			},
 			// Test import.
 			{
-				ResourceName:      resourceName,
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"created_at"},
 			},
 		},
 	})
@@ -2084,6 +2084,27 @@ func validate4ByteAsn(v interface{}, k string) (ws []string, errors []error) {
 	return
 }
 
+func validateDxBgpAsn(v interface{}, k string) (ws []string, errors []error) {
+	asn := int64(v.(int))
+
+	if asn < 1 || asn > 4294967294 {
+		errors = append(errors, fmt.Errorf("%q (%d) must be in the range 1 to 4294967294. An ASN in asdot notation (X.Y) must be converted to asplain notation (X * 65536 + Y)", k, asn))
+	}
+	return
+}
+
+// validateDxVirtualInterfaceNamePrefix ensures that a name generated from the prefix
+// does not exceed the maximum virtual interface name length of 100 characters.
+func validateDxVirtualInterfaceNamePrefix(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	prefixMaxLength := 100 - resource.UniqueIDSuffixLength
+	if len(value) < 1 || len(value) > prefixMaxLength {
+		errors = append(errors, fmt.Errorf(
+			"%q must be between 1 and %d characters", k, prefixMaxLength))
+	}
+	return
+}
+
 func validateLinuxFileMode(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 	if !regexp.MustCompile(`^[0-7]{4}$`).MatchString(value) {
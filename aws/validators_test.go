@@ -2728,6 +2728,60 @@ func TestValidate4ByteAsn(t *testing.T) {
 	}
 }
 
+func TestValidateDxBgpAsn(t *testing.T) {
+	validAsns := []int{
+		1,
+		65000,
+		65535,
+		4259905636, // 65001.100 in asdot notation.
+		4294967294,
+	}
+	for _, v := range validAsns {
+		_, errors := validateDxBgpAsn(v, "bgp_asn")
+		if len(errors) != 0 {
+			t.Fatalf("%d should be a valid ASN: %q", v, errors)
+		}
+	}
+
+	invalidAsns := []int{
+		-1,
+		0,
+		4294967295,
+		9999999999,
+	}
+	for _, v := range invalidAsns {
+		_, errors := validateDxBgpAsn(v, "bgp_asn")
+		if len(errors) == 0 {
+			t.Fatalf("%d should be an invalid ASN", v)
+		}
+	}
+}
+
+func TestValidateDxVirtualInterfaceNamePrefix(t *testing.T) {
+	validNamePrefixes := []string{
+		"a",
+		"tf-acc-test-",
+		strings.Repeat("W", 74),
+	}
+	for _, v := range validNamePrefixes {
+		_, errors := validateDxVirtualInterfaceNamePrefix(v, "name_prefix")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid name prefix: %q", v, errors)
+		}
+	}
+
+	invalidNamePrefixes := []string{
+		"",
+		strings.Repeat("W", 75),
+	}
+	for _, v := range invalidNamePrefixes {
+		_, errors := validateDxVirtualInterfaceNamePrefix(v, "name_prefix")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid name prefix", v)
+		}
+	}
+}
+
 func TestValidateLaunchTemplateName(t *testing.T) {
 	validNames := []string{
 		"fooBAR123",